@@ -67,6 +67,7 @@ func (r *Response) Error() error {
 	} else {
 		// Store the decoded errors
 		respErr.Errors = resp.Errors
+		respErr.ErrorCode = resp.ErrorCode
 	}
 
 	return respErr
@@ -75,7 +76,8 @@ func (r *Response) Error() error {
 // ErrorResponse is the raw structure of errors when they're returned by the
 // HTTP API.
 type ErrorResponse struct {
-	Errors []string
+	Errors    []string
+	ErrorCode string `json:"error_code"`
 }
 
 // ResponseError is the error returned when Vault responds with an error or
@@ -100,6 +102,11 @@ type ResponseError struct {
 	// Errors are the underlying errors returned by Vault.
 	Errors []string
 
+	// ErrorCode is a machine-readable code identifying common failures, such
+	// as "permission_denied", "sealed", "standby", "quota_exceeded" or
+	// "mfa_failed". It is empty when Vault didn't report one.
+	ErrorCode string
+
 	// Namespace path to be reported to the client if it is set to anything other
 	// than root
 	NamespacePath string
@@ -118,6 +118,25 @@ func (c *Sys) UnmountWithContext(ctx context.Context, path string) error {
 	return err
 }
 
+// RecoverMount wraps RecoverMountWithContext using context.Background.
+func (c *Sys) RecoverMount(path string) error {
+	return c.RecoverMountWithContext(context.Background(), path)
+}
+
+// RecoverMountWithContext restores a mount that is pending deletion.
+func (c *Sys) RecoverMountWithContext(ctx context.Context, path string) error {
+	ctx, cancelFunc := c.c.withConfiguredTimeout(ctx)
+	defer cancelFunc()
+
+	r := c.c.NewRequest(http.MethodPost, fmt.Sprintf("/v1/sys/mounts/%s/recover", path))
+
+	resp, err := c.c.rawRequestWithContext(ctx, r)
+	if err == nil {
+		defer resp.Body.Close()
+	}
+	return err
+}
+
 // Remount wraps RemountWithContext using context.Background.
 func (c *Sys) Remount(from, to string) error {
 	return c.RemountWithContext(context.Background(), from, to)
@@ -306,6 +325,8 @@ type MountConfigInput struct {
 	UserLockoutConfig         *UserLockoutConfigInput `json:"user_lockout_config,omitempty"`
 	DelegatedAuthAccessors    []string                `json:"delegated_auth_accessors,omitempty" mapstructure:"delegated_auth_accessors"`
 	IdentityTokenKey          string                  `json:"identity_token_key,omitempty" mapstructure:"identity_token_key"`
+	RollbackInterval          string                  `json:"rollback_interval,omitempty" mapstructure:"rollback_interval"`
+	DeletionGracePeriod       string                  `json:"deletion_grace_period,omitempty" mapstructure:"deletion_grace_period"`
 
 	// Deprecated: This field will always be blank for newer server responses.
 	PluginName string `json:"plugin_name,omitempty" mapstructure:"plugin_name"`
@@ -325,6 +346,7 @@ type MountOutput struct {
 	RunningVersion        string            `json:"running_plugin_version" mapstructure:"running_plugin_version"`
 	RunningSha256         string            `json:"running_sha256" mapstructure:"running_sha256"`
 	DeprecationStatus     string            `json:"deprecation_status" mapstructure:"deprecation_status"`
+	PurgeTime             string            `json:"purge_time,omitempty" mapstructure:"purge_time"`
 }
 
 type MountConfigOutput struct {
@@ -341,6 +363,8 @@ type MountConfigOutput struct {
 	UserLockoutConfig         *UserLockoutConfigOutput `json:"user_lockout_config,omitempty"`
 	DelegatedAuthAccessors    []string                 `json:"delegated_auth_accessors,omitempty" mapstructure:"delegated_auth_accessors"`
 	IdentityTokenKey          string                   `json:"identity_token_key,omitempty" mapstructure:"identity_token_key"`
+	RollbackInterval          int                      `json:"rollback_interval,omitempty" mapstructure:"rollback_interval"`
+	DeletionGracePeriod       int                      `json:"deletion_grace_period,omitempty" mapstructure:"deletion_grace_period"`
 
 	// Deprecated: This field will always be blank for newer server responses.
 	PluginName string `json:"plugin_name,omitempty" mapstructure:"plugin_name"`
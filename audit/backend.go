@@ -256,7 +256,6 @@ func hasEnterpriseAuditOptions(options map[string]string) bool {
 	enterpriseAuditOptions := []string{
 		optionExclude,
 		optionFallback,
-		optionFilter,
 	}
 
 	for _, o := range enterpriseAuditOptions {
@@ -5,7 +5,12 @@
 
 package audit
 
-import "github.com/hashicorp/vault/internal/observability/event"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/internal/observability/event"
+)
 
 type backendEnt struct{}
 
@@ -17,8 +22,28 @@ func (b *backendEnt) IsFallback() bool {
 	return false
 }
 
-// configureFilterNode is a no-op as filters are an Enterprise-only feature.
-func (b *backend) configureFilterNode(_ string) error {
+// configureFilterNode is used to configure a filter node and associated ID on
+// the Backend. Empty (including whitespace) filters skip configuration of the
+// node, so every entry reaches the sink.
+func (b *backend) configureFilterNode(filter string) error {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil
+	}
+
+	filterNodeID, err := event.GenerateNodeID()
+	if err != nil {
+		return fmt.Errorf("error generating random NodeID for filter node: %w: %w", ErrInternal, err)
+	}
+
+	filterNode, err := newEntryFilter(filter)
+	if err != nil {
+		return fmt.Errorf("error creating filter node: %w", err)
+	}
+
+	b.nodeIDList = append(b.nodeIDList, filterNodeID)
+	b.nodeMap[filterNodeID] = filterNode
+
 	return nil
 }
 
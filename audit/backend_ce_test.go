@@ -15,28 +15,35 @@ import (
 // TestBackend_configureFilterNode ensures that configureFilterNode handles various
 // filter values as expected. Empty (including whitespace) strings should return
 // no error but skip configuration of the node.
-// NOTE: Audit filtering is an Enterprise feature and behaves differently in the
-// community edition of Vault.
 func TestBackend_configureFilterNode(t *testing.T) {
 	t.Parallel()
 
 	tests := map[string]struct {
-		filter string
+		filter           string
+		shouldSkipNode   bool
+		wantErr          bool
+		expectedErrorMsg string
 	}{
 		"happy": {
 			filter: "operation == \"update\"",
 		},
 		"empty": {
-			filter: "",
+			filter:         "",
+			shouldSkipNode: true,
 		},
 		"spacey": {
-			filter: "    ",
+			filter:         "    ",
+			shouldSkipNode: true,
 		},
 		"bad": {
-			filter: "___qwerty",
+			filter:           "___qwerty",
+			wantErr:          true,
+			expectedErrorMsg: "error creating filter node: cannot create new audit filter",
 		},
 		"unsupported-field": {
-			filter: "foo == bar",
+			filter:           "foo == bar",
+			wantErr:          true,
+			expectedErrorMsg: "filter references an unsupported field: foo == bar",
 		},
 	}
 	for name, tc := range tests {
@@ -51,9 +58,25 @@ func TestBackend_configureFilterNode(t *testing.T) {
 			}
 
 			err := b.configureFilterNode(tc.filter)
-			require.NoError(t, err)
-			require.Len(t, b.nodeIDList, 0)
-			require.Len(t, b.nodeMap, 0)
+
+			switch {
+			case tc.wantErr:
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.expectedErrorMsg)
+				require.Len(t, b.nodeIDList, 0)
+				require.Len(t, b.nodeMap, 0)
+			case tc.shouldSkipNode:
+				require.NoError(t, err)
+				require.Len(t, b.nodeIDList, 0)
+				require.Len(t, b.nodeMap, 0)
+			default:
+				require.NoError(t, err)
+				require.Len(t, b.nodeIDList, 1)
+				require.Len(t, b.nodeMap, 1)
+				id := b.nodeIDList[0]
+				node := b.nodeMap[id]
+				require.Equal(t, eventlogger.NodeTypeFilter, node.Type())
+			}
 		})
 	}
 }
@@ -16,8 +16,8 @@ import (
 )
 
 // TestFileBackend_newFileBackend_fallback ensures that we get the correct errors
-// in CE when we try to enable a fileBackend with enterprise options like fallback
-// and filter.
+// in CE when we try to enable a fileBackend with enterprise options like fallback,
+// even when combined with a filter.
 func TestFileBackend_newFileBackend_fallback(t *testing.T) {
 	t.Parallel()
 
@@ -76,8 +76,8 @@ func TestFileBackend_newFileBackend_fallback(t *testing.T) {
 }
 
 // TestFileBackend_newFileBackend_FilterFormatterSink ensures that when configuring
-// a backend in community edition we cannot configure a filter node.
-// We can verify that we have formatter and sink nodes added to the backend.
+// a backend in community edition we can configure a filter node.
+// We can verify that we have filter, formatter and sink nodes added to the backend.
 // The order of calls influences the slice of IDs on the Backend.
 func TestFileBackend_newFileBackend_FilterFormatterSink(t *testing.T) {
 	t.Parallel()
@@ -98,24 +98,32 @@ func TestFileBackend_newFileBackend_FilterFormatterSink(t *testing.T) {
 	}
 
 	b, err := newFileBackend(backendConfig, &noopHeaderFormatter{})
-	require.Error(t, err)
-	require.EqualError(t, err, "enterprise-only options supplied: invalid configuration")
-
-	// Try without filter option
-	delete(cfg, "filter")
-	b, err = newFileBackend(backendConfig, &noopHeaderFormatter{})
 	require.NoError(t, err)
 
-	require.Len(t, b.nodeIDList, 2)
-	require.Len(t, b.nodeMap, 2)
+	require.Len(t, b.nodeIDList, 3)
+	require.Len(t, b.nodeMap, 3)
+	require.True(t, b.HasFiltering())
 
 	id := b.nodeIDList[0]
 	node := b.nodeMap[id]
-	require.Equal(t, eventlogger.NodeTypeFormatter, node.Type())
+	require.Equal(t, eventlogger.NodeTypeFilter, node.Type())
 
 	id = b.nodeIDList[1]
 	node = b.nodeMap[id]
+	require.Equal(t, eventlogger.NodeTypeFormatter, node.Type())
+
+	id = b.nodeIDList[2]
+	node = b.nodeMap[id]
 	require.Equal(t, eventlogger.NodeTypeSink, node.Type())
+
+	// Try without filter option
+	delete(cfg, "filter")
+	b, err = newFileBackend(backendConfig, &noopHeaderFormatter{})
+	require.NoError(t, err)
+
+	require.Len(t, b.nodeIDList, 2)
+	require.Len(t, b.nodeMap, 2)
+	require.False(t, b.HasFiltering())
 }
 
 // TestBackend_IsFallback ensures that no CE audit device can be a fallback.
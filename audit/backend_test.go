@@ -169,11 +169,11 @@ func TestBackend_hasEnterpriseAuditOptions(t *testing.T) {
 			},
 			expected: false,
 		},
-		"ent-opt-filter": {
+		"filter": {
 			input: map[string]string{
 				"filter": "mount_type == kv",
 			},
-			expected: true,
+			expected: false,
 		},
 		"ent-opt-fallback": {
 			input: map[string]string{
@@ -213,7 +213,7 @@ func TestBackend_hasEnterpriseAuditOptions(t *testing.T) {
 // an Enterprise or non-Enterprise version of Vault, the options supplied to enable
 // an audit device may or may not be valid.
 // NOTE: In the non-Enterprise version of Vault supplying audit options such as
-// 'filter' or 'fallback' is not allowed.
+// 'fallback' or 'exclude' is not allowed.
 func TestBackend_hasInvalidAuditOptions(t *testing.T) {
 	tests := map[string]struct {
 		input    map[string]string
@@ -225,17 +225,11 @@ func TestBackend_hasInvalidAuditOptions(t *testing.T) {
 			},
 			expected: false,
 		},
-		"ent-opt": {
+		"filter": {
 			input: map[string]string{
 				"filter": "mount_type == kv",
 			},
-			expected: !constants.IsEnterprise,
-		},
-		"ent-opt-filter": {
-			input: map[string]string{
-				"filter": "mount_type == kv",
-			},
-			expected: !constants.IsEnterprise,
+			expected: false,
 		},
 		"ent-opt-fallback": {
 			input: map[string]string{
@@ -35,10 +35,14 @@ func (b *Broker) handlePipelineDeregistration(ctx context.Context, name string)
 }
 
 // requiredSuccessThresholdSinks is the value that should be used as the success
-// threshold in the eventlogger broker.
+// threshold in the eventlogger broker. Entries may legitimately be filtered out
+// by every device that has a filter, so a sink is only required to succeed when
+// at least one device without a filter is registered.
 func (b *Broker) requiredSuccessThresholdSinks() int {
-	if len(b.backends) > 0 {
-		return 1
+	for _, be := range b.backends {
+		if !be.backend.HasFiltering() {
+			return 1
+		}
 	}
 
 	return 0
@@ -6,6 +6,8 @@ package audit
 import (
 	"context"
 	"crypto/sha256"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -207,3 +209,54 @@ func TestBroker_getAuditContext(t *testing.T) {
 	auditCancel()
 	require.NotNil(t, auditContext.Err())
 }
+
+// TestAuditBroker_LogRequest_Filtered ensures that entries filtered out by every
+// registered device don't cause the request to fail, and that entries matching
+// the filter are still written.
+func TestAuditBroker_LogRequest_Filtered(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	backendConfig := &BackendConfig{
+		Config: map[string]string{
+			"file_path": path,
+			"filter":    "operation == \"read\"",
+		},
+		MountPath:  "test",
+		SaltConfig: &salt.Config{},
+		SaltView:   &logical.InmemStorage{},
+		Logger:     hclog.NewNullLogger(),
+	}
+
+	sink, err := NewFileBackend(backendConfig, &noopHeaderFormatter{})
+	require.NoError(t, err)
+
+	broker, err := NewBroker(corehelpers.NewTestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, broker.Register(sink, false))
+
+	in := &logical.LogInput{
+		Auth: &logical.Auth{
+			ClientToken: "foo",
+			Accessor:    "bar",
+			Policies:    []string{"root"},
+			TokenType:   logical.TokenTypeService,
+		},
+		Request: &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "foo",
+		},
+	}
+
+	ctx := nshelper.RootContext(context.Background())
+	require.NoError(t, broker.LogRequest(ctx, in))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Empty(t, b)
+
+	in.Request.Operation = logical.ReadOperation
+	require.NoError(t, broker.LogRequest(ctx, in))
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, b)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
@@ -125,6 +126,16 @@ token, separated by semicolons. Available in Consul 1.5 or above.`,
 				Description: `List of Node Identities to attach to the
 token. Available in Consul 1.8.1 or above.`,
 			},
+
+			"identity_templating": {
+				Type: framework.TypeBool,
+				Description: `If set, consul_policies, consul_roles, service_identities
+and node_identities may contain identity templates, e.g.
+{{identity.entity.metadata.app}}, that are rendered for the entity requesting
+credentials. Templated values must render to letters, digits, dashes and
+underscores only.`,
+				Default: false,
+			},
 		},
 
 		Callbacks: map[logical.Operation]framework.OperationFunc{
@@ -167,13 +178,14 @@ func (b *backend) pathRolesRead(ctx context.Context, req *logical.Request, d *fr
 	// Generate the response
 	resp := &logical.Response{
 		Data: map[string]interface{}{
-			"lease":            int64(roleConfigData.TTL.Seconds()),
-			"ttl":              int64(roleConfigData.TTL.Seconds()),
-			"max_ttl":          int64(roleConfigData.MaxTTL.Seconds()),
-			"token_type":       roleConfigData.TokenType,
-			"local":            roleConfigData.Local,
-			"consul_namespace": roleConfigData.ConsulNamespace,
-			"partition":        roleConfigData.Partition,
+			"lease":               int64(roleConfigData.TTL.Seconds()),
+			"ttl":                 int64(roleConfigData.TTL.Seconds()),
+			"max_ttl":             int64(roleConfigData.MaxTTL.Seconds()),
+			"token_type":          roleConfigData.TokenType,
+			"local":               roleConfigData.Local,
+			"consul_namespace":    roleConfigData.ConsulNamespace,
+			"partition":           roleConfigData.Partition,
+			"identity_templating": roleConfigData.IdentityTemplating,
 		},
 	}
 	if roleConfigData.Policy != "" {
@@ -203,6 +215,7 @@ func (b *backend) pathRolesWrite(ctx context.Context, req *logical.Request, d *f
 	roles := d.Get("consul_roles").([]string)
 	serviceIdentities := d.Get("service_identities").([]string)
 	nodeIdentities := d.Get("node_identities").([]string)
+	identityTemplating := d.Get("identity_templating").(bool)
 
 	switch tokenType {
 	case "client":
@@ -226,6 +239,21 @@ func (b *backend) pathRolesWrite(ctx context.Context, req *logical.Request, d *f
 			"Error decoding policy base64: %s", err)), nil
 	}
 
+	if identityTemplating {
+		if strings.Contains(string(policyRaw), "{{") {
+			return logical.ErrorResponse("identity templates are not supported in the policy document"), nil
+		}
+		templates := append([]string{}, consulPolicies...)
+		templates = append(templates, roles...)
+		templates = append(templates, serviceIdentities...)
+		templates = append(templates, nodeIdentities...)
+		for _, tpl := range templates {
+			if _, err := framework.ValidateIdentityTemplate(tpl); err != nil {
+				return logical.ErrorResponse(fmt.Sprintf("invalid identity template %q: %s", tpl, err)), nil
+			}
+		}
+	}
+
 	var ttl time.Duration
 	ttlRaw, ok := d.GetOk("ttl")
 	if ok {
@@ -248,17 +276,18 @@ func (b *backend) pathRolesWrite(ctx context.Context, req *logical.Request, d *f
 	namespace := d.Get("consul_namespace").(string)
 	partition := d.Get("partition").(string)
 	entry, err := logical.StorageEntryJSON("policy/"+name, roleConfig{
-		Policy:            string(policyRaw),
-		Policies:          consulPolicies,
-		ConsulRoles:       roles,
-		ServiceIdentities: serviceIdentities,
-		NodeIdentities:    nodeIdentities,
-		TokenType:         tokenType,
-		TTL:               ttl,
-		MaxTTL:            maxTTL,
-		Local:             local,
-		ConsulNamespace:   namespace,
-		Partition:         partition,
+		Policy:             string(policyRaw),
+		Policies:           consulPolicies,
+		ConsulRoles:        roles,
+		ServiceIdentities:  serviceIdentities,
+		NodeIdentities:     nodeIdentities,
+		TokenType:          tokenType,
+		TTL:                ttl,
+		MaxTTL:             maxTTL,
+		Local:              local,
+		ConsulNamespace:    namespace,
+		Partition:          partition,
+		IdentityTemplating: identityTemplating,
 	})
 	if err != nil {
 		return nil, err
@@ -280,15 +309,16 @@ func (b *backend) pathRolesDelete(ctx context.Context, req *logical.Request, d *
 }
 
 type roleConfig struct {
-	Policy            string        `json:"policy"`
-	Policies          []string      `json:"policies"`
-	ConsulRoles       []string      `json:"consul_roles"`
-	ServiceIdentities []string      `json:"service_identities"`
-	NodeIdentities    []string      `json:"node_identities"`
-	TTL               time.Duration `json:"lease"`
-	MaxTTL            time.Duration `json:"max_ttl"`
-	TokenType         string        `json:"token_type"`
-	Local             bool          `json:"local"`
-	ConsulNamespace   string        `json:"consul_namespace"`
-	Partition         string        `json:"partition"`
+	Policy             string        `json:"policy"`
+	Policies           []string      `json:"policies"`
+	ConsulRoles        []string      `json:"consul_roles"`
+	ServiceIdentities  []string      `json:"service_identities"`
+	NodeIdentities     []string      `json:"node_identities"`
+	TTL                time.Duration `json:"lease"`
+	MaxTTL             time.Duration `json:"max_ttl"`
+	TokenType          string        `json:"token_type"`
+	Local              bool          `json:"local"`
+	ConsulNamespace    string        `json:"consul_namespace"`
+	Partition          string        `json:"partition"`
+	IdentityTemplating bool          `json:"identity_templating"`
 }
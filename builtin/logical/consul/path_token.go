@@ -6,6 +6,7 @@ package consul
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -60,6 +61,12 @@ func (b *backend) pathTokenRead(ctx context.Context, req *logical.Request, d *fr
 		roleConfigData.TokenType = "client"
 	}
 
+	if roleConfigData.IdentityTemplating {
+		if err := b.renderIdentityTemplates(req, &roleConfigData); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
 	// Get the consul client
 	c, userErr, intErr := b.client(ctx, req.Storage)
 	if intErr != nil {
@@ -149,6 +156,79 @@ func (b *backend) pathTokenRead(ctx context.Context, req *logical.Request, d *fr
 	return s, nil
 }
 
+// identityTemplateValueRe matches what a templated name may render to. The
+// rendered names are the names of Consul policies, roles, services, nodes and
+// datacenters, so this keeps values coming from entity or alias metadata from
+// adding datacenters or otherwise changing what the token is granted.
+var identityTemplateValueRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// renderIdentityTemplates replaces the identity templates in the role's
+// policies, roles and identities with the values for the entity making the
+// request.
+func (b *backend) renderIdentityTemplates(req *logical.Request, role *roleConfig) error {
+	if strings.Contains(role.Policy, "{{") {
+		return fmt.Errorf("identity templates are not supported in the policy document")
+	}
+
+	render := func(tpl string) (string, error) {
+		if !strings.Contains(tpl, "{{") {
+			return tpl, nil
+		}
+		if req.EntityID == "" {
+			return "", fmt.Errorf("role uses identity templates but the request has no entity")
+		}
+		out, err := framework.PopulateIdentityTemplate(tpl, req.EntityID, b.System())
+		if err != nil {
+			return "", fmt.Errorf("template %q could not be rendered: %w", tpl, err)
+		}
+		if !identityTemplateValueRe.MatchString(out) {
+			return "", fmt.Errorf("template %q rendered to an invalid name", tpl)
+		}
+		return out, nil
+	}
+
+	// Identities are rendered per component, so that a rendered value can't
+	// add datacenters
+	renderIdentity := func(tpl string) (string, error) {
+		name, datacenters, found := strings.Cut(tpl, ":")
+		name, err := render(name)
+		if err != nil {
+			return "", err
+		}
+		if !found {
+			return name, nil
+		}
+		dcs := strings.Split(datacenters, ",")
+		for i, dc := range dcs {
+			if dcs[i], err = render(dc); err != nil {
+				return "", err
+			}
+		}
+		return name + ":" + strings.Join(dcs, ","), nil
+	}
+
+	lists := [][]string{role.Policies, role.ConsulRoles, role.ServiceIdentities, role.NodeIdentities}
+	for i, list := range lists {
+		renderFunc := render
+		if i >= 2 {
+			renderFunc = renderIdentity
+		}
+		rendered := make([]string, 0, len(list))
+		for _, tpl := range list {
+			out, err := renderFunc(tpl)
+			if err != nil {
+				return err
+			}
+			rendered = append(rendered, out)
+		}
+		lists[i] = rendered
+	}
+
+	// Only update the role once everything has been rendered
+	role.Policies, role.ConsulRoles, role.ServiceIdentities, role.NodeIdentities = lists[0], lists[1], lists[2], lists[3]
+	return nil
+}
+
 func parseServiceIdentities(data []string) []*api.ACLServiceIdentity {
 	aclServiceIdentities := []*api.ACLServiceIdentity{}
 
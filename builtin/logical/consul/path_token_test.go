@@ -4,10 +4,12 @@
 package consul
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/vault/sdk/logical"
 )
 
 func TestToken_parseServiceIdentities(t *testing.T) {
@@ -105,3 +107,97 @@ func TestToken_parseNodeIdentities(t *testing.T) {
 		})
 	}
 }
+
+func TestToken_renderIdentityTemplates(t *testing.T) {
+	b := Backend()
+	err := b.Setup(context.Background(), &logical.BackendConfig{
+		System: &logical.StaticSystemView{
+			EntityVal: &logical.Entity{
+				ID:   "entity-id",
+				Name: "web",
+				Metadata: map[string]string{
+					"dc": "dc1",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	role := &roleConfig{
+		Policy:            `service "web" { policy = "write" }`,
+		Policies:          []string{"static", "{{identity.entity.name}}-policy"},
+		ServiceIdentities: []string{"{{identity.entity.name}}:{{identity.entity.metadata.dc}}"},
+		NodeIdentities:    []string{"node-1:{{identity.entity.metadata.dc}}"},
+	}
+
+	// Templates can't be rendered without an entity
+	if err := b.renderIdentityTemplates(&logical.Request{}, role); err == nil {
+		t.Fatal("expected error without an entity")
+	}
+
+	if err := b.renderIdentityTemplates(&logical.Request{EntityID: "entity-id"}, role); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &roleConfig{
+		Policy:            `service "web" { policy = "write" }`,
+		Policies:          []string{"static", "web-policy"},
+		ConsulRoles:       []string{},
+		ServiceIdentities: []string{"web:dc1"},
+		NodeIdentities:    []string{"node-1:dc1"},
+	}
+	if !reflect.DeepEqual(role, want) {
+		t.Fatalf("bad rendered role: %#v", role)
+	}
+}
+
+// TestToken_renderIdentityTemplates_invalid verifies that templates are
+// rejected in the policy document, and that metadata values can't change the
+// structure of what is rendered.
+func TestToken_renderIdentityTemplates_invalid(t *testing.T) {
+	b := Backend()
+	err := b.Setup(context.Background(), &logical.BackendConfig{
+		System: &logical.StaticSystemView{
+			EntityVal: &logical.Entity{
+				ID: "entity-id",
+				Metadata: map[string]string{
+					"quoted":    `web" } service_prefix "" { policy = "write`,
+					"multi_dc":  "dc1,dc2",
+					"separated": "web:dc2",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]*roleConfig{
+		"policy document": {
+			Policy: `service "{{identity.entity.metadata.quoted}}" { policy = "read" }`,
+		},
+		"quoted policy name": {
+			Policies: []string{"{{identity.entity.metadata.quoted}}"},
+		},
+		"datacenter list": {
+			ServiceIdentities: []string{"web:{{identity.entity.metadata.multi_dc}}"},
+		},
+		"identity separator": {
+			NodeIdentities: []string{"{{identity.entity.metadata.separated}}"},
+		},
+	}
+
+	for name, role := range cases {
+		t.Run(name, func(t *testing.T) {
+			orig := *role
+			if err := b.renderIdentityTemplates(&logical.Request{EntityID: "entity-id"}, role); err == nil {
+				t.Fatal("expected error")
+			}
+			if !reflect.DeepEqual(*role, orig) {
+				t.Fatalf("role was modified: %#v", role)
+			}
+		})
+	}
+}
@@ -11,10 +11,14 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/command/agentproxyshared/auth"
+	"github.com/hashicorp/vault/sdk/helper/parseutil"
 )
 
 const (
@@ -33,6 +37,16 @@ type kubernetesMethod struct {
 
 	// jwtData is a ReadCloser used to inject a ReadCloser for mocking tests.
 	jwtData io.ReadCloser
+
+	// The fields below are only set when token_read_period is configured, in
+	// which case the token file is watched for rotation.
+	credsFound      chan struct{}
+	stopCh          chan struct{}
+	doneCh          chan struct{}
+	credSuccessGate chan struct{}
+	ticker          *time.Ticker
+	once            *sync.Once
+	latestToken     *atomic.Value
 }
 
 // NewKubernetesAuthMethod reads the user configuration and returns a configured
@@ -71,6 +85,27 @@ func NewKubernetesAuthMethod(conf *auth.AuthConfig) (auth.AuthMethod, error) {
 		return nil, errors.New("'role' value is empty")
 	}
 
+	if readPeriodRaw, ok := conf.Config["token_read_period"]; ok {
+		readPeriod, err := parseutil.ParseDurationSecond(readPeriodRaw)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing 'token_read_period' value: %w", err)
+		}
+		if readPeriod <= 0 {
+			return nil, errors.New("'token_read_period' value must be positive")
+		}
+
+		k.credsFound = make(chan struct{})
+		k.stopCh = make(chan struct{})
+		k.doneCh = make(chan struct{})
+		k.credSuccessGate = make(chan struct{})
+		k.once = new(sync.Once)
+		k.latestToken = new(atomic.Value)
+		k.latestToken.Store("")
+		k.ticker = time.NewTicker(readPeriod)
+
+		go k.runWatcher()
+	}
+
 	return k, nil
 }
 
@@ -82,6 +117,10 @@ func (k *kubernetesMethod) Authenticate(ctx context.Context, client *api.Client)
 		return "", nil, nil, fmt.Errorf("error reading JWT with Kubernetes Auth: %w", err)
 	}
 
+	if k.latestToken != nil {
+		k.latestToken.Store(jwtString)
+	}
+
 	return fmt.Sprintf("%s/login", k.mountPath), nil, map[string]interface{}{
 		"role": k.role,
 		"jwt":  jwtString,
@@ -89,13 +128,63 @@ func (k *kubernetesMethod) Authenticate(ctx context.Context, client *api.Client)
 }
 
 func (k *kubernetesMethod) NewCreds() chan struct{} {
-	return nil
+	return k.credsFound
 }
 
 func (k *kubernetesMethod) CredSuccess() {
+	if k.once != nil {
+		k.once.Do(func() {
+			close(k.credSuccessGate)
+		})
+	}
 }
 
 func (k *kubernetesMethod) Shutdown() {
+	if k.ticker != nil {
+		k.ticker.Stop()
+		close(k.stopCh)
+		<-k.doneCh
+	}
+}
+
+// runWatcher periodically re-reads the service account token and signals
+// that new credentials are available when the token has been rotated, so that
+// auto-auth logs in again with the fresh token.
+func (k *kubernetesMethod) runWatcher() {
+	defer close(k.doneCh)
+
+	select {
+	case <-k.stopCh:
+		return
+
+	case <-k.credSuccessGate:
+		// Only start watching once the initial authentication has succeeded,
+		// so the token read at startup is not reported as a rotation
+	}
+
+	for {
+		select {
+		case <-k.stopCh:
+			return
+
+		case <-k.ticker.C:
+			jwtString, err := k.readJWT()
+			if err != nil {
+				k.logger.Warn("error re-reading service account token", "error", err)
+				continue
+			}
+			if jwtString == "" || jwtString == k.latestToken.Load().(string) {
+				continue
+			}
+
+			k.logger.Debug("service account token rotated")
+			select {
+			case k.credsFound <- struct{}{}:
+			case <-k.stopCh:
+				return
+			}
+		}
+	}
 }
 
 // readJWT reads the JWT data for the Agent to submit to Vault. The default is
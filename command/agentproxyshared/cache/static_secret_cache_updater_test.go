@@ -131,7 +131,7 @@ func TestNewStaticSecretCacheUpdater(t *testing.T) {
 }
 
 // TestOpenWebSocketConnection tests that the openWebSocketConnection function
-// works as expected (fails on CE, succeeds on ent).
+// works as expected.
 // This uses a TLS enabled (wss) WebSocket connection.
 func TestOpenWebSocketConnection(t *testing.T) {
 	t.Parallel()
@@ -143,13 +143,8 @@ func TestOpenWebSocketConnection(t *testing.T) {
 	updater.tokenSink.WriteToken(client.Token())
 
 	conn, err := updater.openWebSocketConnection(context.Background())
-	if constants.IsEnterprise {
-		require.NoError(t, err)
-		require.NotNil(t, conn)
-	} else {
-		require.Nil(t, conn)
-		require.Errorf(t, err, "ensure Vault is Enterprise version 1.16 or above")
-	}
+	require.NoError(t, err)
+	require.NotNil(t, conn)
 }
 
 // TestOpenWebSocketConnection_BadPolicyToken tests attempting to open a websocket
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package socket
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vault/command/agentproxyshared/sink"
+)
+
+// writeTimeout bounds how long a single client may take to read the token.
+const writeTimeout = 5 * time.Second
+
+// socketSink is a Sink implementation that serves the latest token over a unix
+// socket. Each client that connects is sent the token and disconnected, so the
+// token never touches the filesystem.
+type socketSink struct {
+	path     string
+	mode     os.FileMode
+	owner    int
+	group    int
+	logger   hclog.Logger
+	listener net.Listener
+
+	l     sync.RWMutex
+	token string
+
+	doneCh chan struct{}
+}
+
+// NewSocketSink creates a new socket sink with the given configuration
+func NewSocketSink(conf *sink.SinkConfig) (sink.Sink, error) {
+	if conf.Logger == nil {
+		return nil, errors.New("nil logger provided")
+	}
+
+	conf.Logger.Info("creating socket sink")
+
+	s := &socketSink{
+		logger: conf.Logger,
+		mode:   0o600,
+		owner:  os.Getuid(),
+		group:  os.Getgid(),
+		doneCh: make(chan struct{}),
+	}
+
+	pathRaw, ok := conf.Config["path"]
+	if !ok {
+		return nil, errors.New("'path' not specified for socket sink")
+	}
+	path, ok := pathRaw.(string)
+	if !ok {
+		return nil, errors.New("could not parse 'path' as string")
+	}
+
+	s.path = path
+
+	if modeRaw, ok := conf.Config["mode"]; ok {
+		s.logger.Debug("verifying override for default socket sink mode")
+		mode, typeOK := modeRaw.(int)
+		if !typeOK {
+			return nil, errors.New("could not parse 'mode' as integer")
+		}
+
+		if os.FileMode(mode)&^os.ModePerm != 0 {
+			return nil, fmt.Errorf("socket mode must only contain permission bits")
+		}
+
+		s.logger.Debug("overriding default socket sink", "mode", mode)
+		s.mode = os.FileMode(mode)
+	}
+
+	if ownerRaw, ok := conf.Config["owner"]; ok {
+		owner, typeOK := ownerRaw.(int)
+		if !typeOK {
+			return nil, errors.New("could not parse 'owner' as integer")
+		}
+
+		s.logger.Debug("overriding default socket sink", "owner", owner)
+		s.owner = owner
+	}
+
+	if groupRaw, ok := conf.Config["group"]; ok {
+		group, typeOK := groupRaw.(int)
+		if !typeOK {
+			return nil, errors.New("could not parse 'group' as integer")
+		}
+
+		s.logger.Debug("overriding default socket sink", "group", group)
+		s.group = group
+	}
+
+	if err := s.listen(); err != nil {
+		return nil, err
+	}
+
+	go s.serve()
+
+	s.logger.Info("socket sink configured", "path", s.path, "mode", s.mode, "owner", s.owner, "group", s.group)
+
+	return s, nil
+}
+
+// listen creates the unix socket, replacing a stale socket left behind by a
+// previous run. Any other kind of file at the path is left alone. The socket
+// is created in a private directory and only moved to its path once its mode
+// and ownership are set, so no other user can connect to it in the meantime.
+func (s *socketSink) listen() error {
+	if fi, err := os.Lstat(s.path); err == nil {
+		if fi.Mode().Type() != fs.ModeSocket {
+			return fmt.Errorf("%s exists and is not a socket", s.path)
+		}
+		if err := os.Remove(s.path); err != nil {
+			return fmt.Errorf("error removing stale socket %s: %w", s.path, err)
+		}
+	}
+
+	// MkdirTemp creates the directory with mode 0700
+	dir, err := os.MkdirTemp(filepath.Dir(s.path), ".sink-*")
+	if err != nil {
+		return fmt.Errorf("error creating directory for %s: %w", s.path, err)
+	}
+	defer os.RemoveAll(dir)
+	tmpPath := filepath.Join(dir, "s")
+
+	listener, err := net.Listen("unix", tmpPath)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", s.path, err)
+	}
+	// The socket is moved, so it's removed by Close instead
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if err := os.Chmod(tmpPath, s.mode); err != nil {
+		listener.Close()
+		return fmt.Errorf("error changing mode of %s: %w", s.path, err)
+	}
+	if err := os.Chown(tmpPath, s.owner, s.group); err != nil {
+		listener.Close()
+		return fmt.Errorf("error changing ownership of %s: %w", s.path, err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		listener.Close()
+		return fmt.Errorf("error moving socket to %s: %w", s.path, err)
+	}
+
+	s.listener = listener
+	return nil
+}
+
+func (s *socketSink) serve() {
+	defer close(s.doneCh)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.logger.Warn("error accepting socket sink connection", "error", err)
+			continue
+		}
+
+		// Serve each client separately, so a stalled client doesn't delay
+		// the others
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveConn(conn)
+		}()
+	}
+}
+
+// serveConn writes the current token to the client and disconnects it.
+func (s *socketSink) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	s.l.RLock()
+	token := s.token
+	s.l.RUnlock()
+
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := conn.Write([]byte(token)); err != nil {
+		s.logger.Warn("error writing token to socket sink connection", "error", err)
+	}
+}
+
+// WriteToken implements the Server interface and stores the token to be served
+// to clients that connect to the socket. A blank token is ignored, so clients
+// keep receiving the last known token.
+func (s *socketSink) WriteToken(token string) error {
+	s.logger.Trace("enter write_token", "path", s.path)
+	defer s.logger.Trace("exit write_token", "path", s.path)
+
+	if token == "" {
+		return nil
+	}
+
+	s.l.Lock()
+	s.token = token
+	s.l.Unlock()
+
+	s.logger.Info("token served by socket sink", "path", s.path)
+
+	return nil
+}
+
+// Close stops serving the token and removes the socket.
+func (s *socketSink) Close() error {
+	err := s.listener.Close()
+	<-s.doneCh
+	if rmErr := os.Remove(s.path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) && err == nil {
+		err = rmErr
+	}
+	return err
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package socket
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	hclog "github.com/hashicorp/go-hclog"
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/command/agentproxyshared/sink"
+	"github.com/hashicorp/vault/sdk/helper/logging"
+)
+
+func testSocketSink(t *testing.T, config map[string]interface{}) (sink.Sink, string) {
+	t.Helper()
+
+	// Unix socket paths are limited in length, so avoid the long default
+	// test temp directory
+	tmpDir, err := os.MkdirTemp("", "sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
+
+	path := filepath.Join(tmpDir, "token.sock")
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	config["path"] = path
+
+	s, err := NewSocketSink(&sink.SinkConfig{
+		Logger: logging.NewVaultLogger(hclog.Trace).Named("sink.socket"),
+		Config: config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.(io.Closer).Close() })
+
+	return s, path
+}
+
+func readSocket(t *testing.T, path string) string {
+	t.Helper()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	b, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestSocketSink(t *testing.T) {
+	s, path := testSocketSink(t, nil)
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0o600 {
+		t.Fatalf("wrong mode: %o", fi.Mode().Perm())
+	}
+
+	// The private directory the socket was created in is removed
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the socket, got %d entries", len(entries))
+	}
+
+	// Nothing is served until a token has been written
+	if token := readSocket(t, path); token != "" {
+		t.Fatalf("expected no token, got %q", token)
+	}
+
+	uuidStr, _ := uuid.GenerateUUID()
+	if err := s.WriteToken(uuidStr); err != nil {
+		t.Fatal(err)
+	}
+	if token := readSocket(t, path); token != uuidStr {
+		t.Fatalf("expected %q, got %q", uuidStr, token)
+	}
+
+	// A blank token keeps the last known token
+	if err := s.WriteToken(""); err != nil {
+		t.Fatal(err)
+	}
+	if token := readSocket(t, path); token != uuidStr {
+		t.Fatalf("expected %q, got %q", uuidStr, token)
+	}
+
+	if err := s.(io.Closer).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected socket to be removed, got: %v", err)
+	}
+}
+
+func TestSocketSink_Mode(t *testing.T) {
+	_, path := testSocketSink(t, map[string]interface{}{
+		"mode": 0o660,
+	})
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0o660 {
+		t.Fatalf("wrong mode: %o", fi.Mode().Perm())
+	}
+}
+
+func TestSocketSink_RefusesNonSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("keep"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewSocketSink(&sink.SinkConfig{
+		Logger: logging.NewVaultLogger(hclog.Trace),
+		Config: map[string]interface{}{
+			"path": path,
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if b, _ := os.ReadFile(path); string(b) != "keep" {
+		t.Fatal("existing file was modified")
+	}
+}
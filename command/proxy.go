@@ -34,6 +34,7 @@ import (
 	"github.com/hashicorp/vault/command/agentproxyshared/sink"
 	"github.com/hashicorp/vault/command/agentproxyshared/sink/file"
 	"github.com/hashicorp/vault/command/agentproxyshared/sink/inmem"
+	"github.com/hashicorp/vault/command/agentproxyshared/sink/socket"
 	"github.com/hashicorp/vault/command/agentproxyshared/winsvc"
 	proxyConfig "github.com/hashicorp/vault/command/proxy/config"
 	"github.com/hashicorp/vault/helper/logging"
@@ -339,6 +340,25 @@ func (c *ProxyCommand) Run(args []string) int {
 				}
 				config.Sink = s
 				sinks = append(sinks, config)
+			case "socket":
+				config := &sink.SinkConfig{
+					Logger:    c.logger.Named("sink.socket"),
+					Config:    sc.Config,
+					Client:    sinkClient,
+					WrapTTL:   sc.WrapTTL,
+					DHType:    sc.DHType,
+					DeriveKey: sc.DeriveKey,
+					DHPath:    sc.DHPath,
+					AAD:       sc.AAD,
+				}
+				s, err := socket.NewSocketSink(config)
+				if err != nil {
+					c.UI.Error(fmt.Errorf("error creating socket sink: %w", err).Error())
+					return 1
+				}
+				defer s.(io.Closer).Close()
+				config.Sink = s
+				sinks = append(sinks, config)
 			default:
 				c.UI.Error(fmt.Sprintf("Unknown sink type %q", sc.Type))
 				return 1
@@ -830,6 +850,7 @@ func (c *ProxyCommand) Run(args []string) int {
 
 	// Notify systemd that the server is ready (if applicable)
 	c.notifySystemd(systemd.SdNotifyReady)
+	defer startSystemdWatchdog(c.logger, nil)()
 
 	defer func() {
 		if err := c.removePidFile(config.PidFile); err != nil {
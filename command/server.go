@@ -38,6 +38,7 @@ import (
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/go-secure-stdlib/reloadutil"
 	"github.com/hashicorp/vault/audit"
+	"github.com/hashicorp/vault/command/agentproxyshared/winsvc"
 	config2 "github.com/hashicorp/vault/command/config"
 	"github.com/hashicorp/vault/command/server"
 	"github.com/hashicorp/vault/helper/builtinplugins"
@@ -46,6 +47,7 @@ import (
 	loghelper "github.com/hashicorp/vault/helper/logging"
 	"github.com/hashicorp/vault/helper/metricsutil"
 	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/helper/tracing"
 	"github.com/hashicorp/vault/helper/useragent"
 	vaulthttp "github.com/hashicorp/vault/http"
 	"github.com/hashicorp/vault/internalshared/configutil"
@@ -608,6 +610,11 @@ func (c *ServerCommand) runRecoveryMode() int {
 	// Initialize the listeners
 	lns := make([]listenerutil.Listener, 0, len(config.Listeners))
 	for _, lnConfig := range config.Listeners {
+		// Cluster traffic isn't served in recovery mode
+		if lnConfig.Role == "cluster_only" {
+			continue
+		}
+
 		ln, _, _, err := server.NewListener(lnConfig, c.logGate, c.UI)
 		if err != nil {
 			c.UI.Error(fmt.Sprintf("Error initializing listener of type %s: %s", lnConfig.Type, err))
@@ -853,6 +860,39 @@ func (c *ServerCommand) InitListeners(config *server.Config, disableClustering b
 
 	var errMsg error
 	for i, lnConfig := range config.Listeners {
+		switch lnConfig.Role {
+		case "", "default", "metrics_only":
+		case "cluster_only":
+			// Cluster only listeners don't serve the API, their address is
+			// only used to listen for cluster traffic
+			if lnConfig.Type != "tcp" {
+				errMsg = fmt.Errorf("Error initializing listener of type %s: cluster_only listeners must be of type tcp", lnConfig.Type)
+				return 1, nil, nil, errMsg
+			}
+			if disableClustering {
+				errMsg = fmt.Errorf("Error initializing listener of type %s: cluster_only listeners require clustering to be enabled", lnConfig.Type)
+				return 1, nil, nil, errMsg
+			}
+			if lnConfig.ClusterAddress != "" {
+				errMsg = fmt.Errorf("Error initializing listener of type %s: cluster_address cannot be set on cluster_only listeners", lnConfig.Type)
+				return 1, nil, nil, errMsg
+			}
+			tcpAddr, err := net.ResolveTCPAddr("tcp", lnConfig.Address)
+			if err != nil {
+				errMsg = fmt.Errorf("Error resolving cluster_only listener address: %s", err)
+				return 1, nil, nil, errMsg
+			}
+			clusterAddrs = append(clusterAddrs, tcpAddr)
+
+			key := fmt.Sprintf("listener %d", i+1)
+			*infoKeys = append(*infoKeys, key)
+			(*info)[key] = fmt.Sprintf("%s (cluster address: %q, role: %q)", lnConfig.Type, tcpAddr.String(), lnConfig.Role)
+			continue
+		default:
+			errMsg = fmt.Errorf("Error initializing listener of type %s: unknown role %q", lnConfig.Type, lnConfig.Role)
+			return 1, nil, nil, errMsg
+		}
+
 		ln, props, reloadFunc, err := server.NewListener(lnConfig, c.logGate, c.UI)
 		if err != nil {
 			errMsg = fmt.Errorf("Error initializing listener of type %s: %s", lnConfig.Type, err)
@@ -865,7 +905,13 @@ func (c *ServerCommand) InitListeners(config *server.Config, disableClustering b
 			(*c.reloadFuncs)[fmt.Sprintf("listener|%s", lnConfig.Type)] = relSlice
 		}
 
-		if !disableClustering && lnConfig.Type == "tcp" {
+		if lnConfig.Role == "metrics_only" {
+			props["role"] = lnConfig.Role
+		}
+
+		// Metrics only listeners don't serve the API, so don't synthesize a
+		// cluster address from them
+		if !disableClustering && lnConfig.Type == "tcp" && lnConfig.Role != "metrics_only" {
 			addr := lnConfig.ClusterAddress
 			if addr != "" {
 				tcpAddr, err := net.ResolveTCPAddr("tcp", lnConfig.ClusterAddress)
@@ -1210,6 +1256,29 @@ func (c *ServerCommand) Run(args []string) int {
 	}
 	metricsHelper := metricsutil.NewMetricsHelper(inmemMetrics, prometheusEnabled)
 
+	if config.Telemetry != nil && config.Telemetry.OTLPTracesEndpoint != "" {
+		sampleRatio := 1.0
+		if config.Telemetry.OTLPTracesSampleRatio != nil {
+			sampleRatio = *config.Telemetry.OTLPTracesSampleRatio
+		}
+		shutdownTracing, err := tracing.Setup(context.Background(), &tracing.Config{
+			Endpoint:       config.Telemetry.OTLPTracesEndpoint,
+			Insecure:       config.Telemetry.OTLPTracesInsecure,
+			SampleRatio:    sampleRatio,
+			ServiceName:    "vault",
+			ServiceVersion: version.GetVersion().VersionNumber(),
+		})
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error initializing tracing: %s", err))
+			return 1
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				c.logger.Error("error flushing traces", "error", err)
+			}
+		}()
+	}
+
 	// Initialize the Service Discovery, if there is one
 	var configSR sr.ServiceRegistration
 	if config.ServiceRegistration != nil {
@@ -1561,6 +1630,11 @@ func (c *ServerCommand) Run(args []string) int {
 
 	// Notify systemd that the server is ready (if applicable)
 	c.notifySystemd(systemd.SdNotifyReady)
+	// Only feed the watchdog while the core's state lock can be acquired, so a
+	// deadlocked core gets restarted. The lock is held for writing for as long
+	// as an unseal, seal or step-down takes, so skip the check meanwhile,
+	// unless the transition looks stuck.
+	defer startSystemdWatchdog(c.logger, skipCheckDuringTransition(core.StateTransitionStarted, lockCheck(core.HALock())))()
 
 	if c.flagDev {
 		protocol := "http://"
@@ -1619,6 +1693,9 @@ func (c *ServerCommand) Run(args []string) int {
 		case <-c.ShutdownCh:
 			c.UI.Output("==> Vault shutdown triggered")
 			shutdownTriggered = true
+		case <-winsvc.ShutdownChannel():
+			c.UI.Output("==> Vault shutdown triggered by the Windows service manager")
+			shutdownTriggered = true
 		case <-c.SighupCh:
 			c.UI.Output("==> Vault reload triggered")
 
@@ -2211,6 +2288,11 @@ func (c *ServerCommand) detectRedirect(detect physical.RedirectDetect,
 			continue
 		}
 
+		// Only attempt listeners serving the API
+		if list.Role == "metrics_only" || list.Role == "cluster_only" {
+			continue
+		}
+
 		// Check if TLS is disabled
 		if list.TLSDisable {
 			scheme = "http"
@@ -2897,6 +2979,7 @@ func createCoreConfig(c *ServerCommand, config *server.Config, backend physical.
 		Logger:                         c.logger,
 		DetectDeadlocks:                config.DetectDeadlocks,
 		ImpreciseLeaseRoleTracking:     config.ImpreciseLeaseRoleTracking,
+		BarrierPrefixKeyDerivation:     config.BarrierPrefixKeyDerivation,
 		DisableSentinelTrace:           config.DisableSentinelTrace,
 		DisableCache:                   config.DisableCache,
 		DisableMlock:                   config.DisableMlock,
@@ -2908,6 +2991,8 @@ func createCoreConfig(c *ServerCommand, config *server.Config, backend physical.
 		PluginTmpdir:                   config.PluginTmpdir,
 		PluginFileUid:                  config.PluginFileUid,
 		PluginFilePermissions:          config.PluginFilePermissions,
+		PluginDownloadPGPKeys:          config.PluginDownloadPGPKeys,
+		MountDeletionGracePeriod:       config.MountDeletionGracePeriod,
 		EnableUI:                       config.EnableUI,
 		EnableRaw:                      config.EnableRawEndpoint,
 		EnableIntrospection:            config.EnableIntrospectionEndpoint,
@@ -76,6 +76,11 @@ type Config struct {
 	PluginFilePermissions    int         `hcl:"-"`
 	PluginFilePermissionsRaw interface{} `hcl:"plugin_file_permissions,alias:PluginFilePermissions"`
 
+	PluginDownloadPGPKeys []string `hcl:"plugin_download_pgp_keys"`
+
+	MountDeletionGracePeriod    time.Duration `hcl:"-"`
+	MountDeletionGracePeriodRaw interface{}   `hcl:"mount_deletion_grace_period"`
+
 	EnableIntrospectionEndpoint    bool        `hcl:"-"`
 	EnableIntrospectionEndpointRaw interface{} `hcl:"introspection_endpoint,alias:EnableIntrospectionEndpoint"`
 
@@ -109,6 +114,8 @@ type Config struct {
 
 	ImpreciseLeaseRoleTracking bool `hcl:"imprecise_lease_role_tracking"`
 
+	BarrierPrefixKeyDerivation bool `hcl:"barrier_prefix_key_derivation"`
+
 	EnableResponseHeaderRaftNodeID    bool        `hcl:"-"`
 	EnableResponseHeaderRaftNodeIDRaw interface{} `hcl:"enable_response_header_raft_node_id"`
 
@@ -380,6 +387,18 @@ func (c *Config) Merge(c2 *Config) *Config {
 		result.PluginFilePermissionsRaw = c2.PluginFilePermissionsRaw
 	}
 
+	result.PluginDownloadPGPKeys = c.PluginDownloadPGPKeys
+	if len(c2.PluginDownloadPGPKeys) > 0 {
+		result.PluginDownloadPGPKeys = c2.PluginDownloadPGPKeys
+	}
+
+	result.MountDeletionGracePeriod = c.MountDeletionGracePeriod
+	result.MountDeletionGracePeriodRaw = c.MountDeletionGracePeriodRaw
+	if c2.MountDeletionGracePeriodRaw != nil {
+		result.MountDeletionGracePeriod = c2.MountDeletionGracePeriod
+		result.MountDeletionGracePeriodRaw = c2.MountDeletionGracePeriodRaw
+	}
+
 	result.DisablePerformanceStandby = c.DisablePerformanceStandby
 	if c2.DisablePerformanceStandby {
 		result.DisablePerformanceStandby = c2.DisablePerformanceStandby
@@ -415,6 +434,11 @@ func (c *Config) Merge(c2 *Config) *Config {
 		result.ImpreciseLeaseRoleTracking = c2.ImpreciseLeaseRoleTracking
 	}
 
+	result.BarrierPrefixKeyDerivation = c.BarrierPrefixKeyDerivation
+	if c2.BarrierPrefixKeyDerivation {
+		result.BarrierPrefixKeyDerivation = c2.BarrierPrefixKeyDerivation
+	}
+
 	result.EnableResponseHeaderRaftNodeID = c.EnableResponseHeaderRaftNodeID
 	if c2.EnableResponseHeaderRaftNodeID {
 		result.EnableResponseHeaderRaftNodeID = c2.EnableResponseHeaderRaftNodeID
@@ -609,6 +633,15 @@ func ParseConfig(d, source string) (*Config, error) {
 		}
 	}
 
+	if result.MountDeletionGracePeriodRaw != nil {
+		if result.MountDeletionGracePeriod, err = parseutil.ParseDurationSecond(result.MountDeletionGracePeriodRaw); err != nil {
+			return nil, err
+		}
+		if result.MountDeletionGracePeriod < 0 {
+			return nil, errors.New("mount_deletion_grace_period cannot be negative")
+		}
+	}
+
 	if result.EnableUIRaw != nil {
 		if result.EnableUI, err = parseutil.ParseBool(result.EnableUIRaw); err != nil {
 			return nil, err
@@ -1126,6 +1159,10 @@ func (c *Config) Sanitized() map[string]interface{} {
 
 		"plugin_file_permissions": c.PluginFilePermissions,
 
+		"plugin_download_pgp_keys": c.PluginDownloadPGPKeys,
+
+		"mount_deletion_grace_period": c.MountDeletionGracePeriod / time.Second,
+
 		"raw_storage_endpoint": c.EnableRawEndpoint,
 
 		"introspection_endpoint": c.EnableIntrospectionEndpoint,
@@ -1150,6 +1187,8 @@ func (c *Config) Sanitized() map[string]interface{} {
 		"detect_deadlocks": c.DetectDeadlocks,
 
 		"imprecise_lease_role_tracking": c.ImpreciseLeaseRoleTracking,
+
+		"barrier_prefix_key_derivation": c.BarrierPrefixKeyDerivation,
 	}
 	for k, v := range sharedResult {
 		result[k] = v
@@ -74,3 +74,21 @@ func TestRollbackMountPointMetricsConfig(t *testing.T) {
 		})
 	}
 }
+
+// TestOTLPTracesConfig verifies that the OTLP trace export settings are parsed,
+// and that the sample ratio is left unset when not configured.
+func TestOTLPTracesConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := LoadConfigFile("./test-fixtures/telemetry/otlp_traces.hcl")
+	require.NoError(t, err)
+	require.Equal(t, "localhost:4318", config.Telemetry.OTLPTracesEndpoint)
+	require.True(t, config.Telemetry.OTLPTracesInsecure)
+	require.NotNil(t, config.Telemetry.OTLPTracesSampleRatio)
+	require.Equal(t, 0.25, *config.Telemetry.OTLPTracesSampleRatio)
+
+	config, err = LoadConfigFile("./test-fixtures/telemetry/rollback_mount_point.hcl")
+	require.NoError(t, err)
+	require.Empty(t, config.Telemetry.OTLPTracesEndpoint)
+	require.Nil(t, config.Telemetry.OTLPTracesSampleRatio)
+}
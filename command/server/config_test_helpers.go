@@ -772,6 +772,8 @@ func testConfig_Sanitized(t *testing.T) {
 		"experiments":                         []string(nil),
 		"plugin_file_uid":                     0,
 		"plugin_file_permissions":             0,
+		"plugin_download_pgp_keys":            []string(nil),
+		"mount_deletion_grace_period":         0 * time.Second,
 		"disable_printable_check":             false,
 		"disable_sealwrap":                    true,
 		"raw_storage_endpoint":                true,
@@ -851,9 +853,13 @@ func testConfig_Sanitized(t *testing.T) {
 			"num_lease_metrics_buckets":              168,
 			"add_lease_metrics_namespace_labels":     false,
 			"add_mount_point_rollback_metrics":       false,
+			"otlp_traces_endpoint":                   "",
+			"otlp_traces_insecure":                   false,
+			"otlp_traces_sample_ratio":               (*float64)(nil),
 		},
 		"administrative_namespace_path": "admin/",
 		"imprecise_lease_role_tracking": false,
+		"barrier_prefix_key_derivation": false,
 	}
 
 	addExpectedEntSanitizedConfig(expected, []string{"http"})
@@ -0,0 +1,11 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+disable_mlock = true
+ui            = true
+
+telemetry {
+  otlp_traces_endpoint     = "localhost:4318"
+  otlp_traces_insecure     = true
+  otlp_traces_sample_ratio = 0.25
+}
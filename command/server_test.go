@@ -62,6 +62,29 @@ const (
 	badListenerWriteTimeout      = `http_write_timeout = "56lbs"`
 	badListenerIdleTimeout       = `http_idle_timeout = "78gophers"`
 
+	metricsOnlyListenerHCL = `
+listener "tcp" {
+	address     = "127.0.0.1:0"
+	tls_disable = "true"
+	role        = "metrics_only"
+}
+`
+
+	clusterOnlyListenerHCL = `
+listener "tcp" {
+	address = "127.0.0.1:0"
+	role    = "cluster_only"
+}
+`
+
+	badClusterOnlyListenerHCL = `
+listener "tcp" {
+	address         = "127.0.0.1:0"
+	cluster_address = "127.0.0.1:0"
+	role            = "cluster_only"
+}
+`
+
 	inmemHCL = `
 backend "inmem_ha" {
   advertise_addr       = "http://127.0.0.1:8200"
@@ -254,6 +277,34 @@ func TestServer(t *testing.T) {
 			1,
 			[]string{"-test-server-config"},
 		},
+		{
+			"metrics_only_listener",
+			testBaseHCL(t, "") + metricsOnlyListenerHCL + inmemHCL,
+			`role: "metrics_only"`,
+			0,
+			[]string{"-test-server-config"},
+		},
+		{
+			"cluster_only_listener",
+			testBaseHCL(t, "") + clusterOnlyListenerHCL + inmemHCL,
+			`role: "cluster_only"`,
+			0,
+			[]string{"-test-server-config"},
+		},
+		{
+			"bad_cluster_only_listener",
+			testBaseHCL(t, "") + badClusterOnlyListenerHCL + inmemHCL,
+			"cluster_address cannot be set on cluster_only listeners",
+			1,
+			[]string{"-test-server-config"},
+		},
+		{
+			"unknown_listener_role",
+			testBaseHCL(t, `role = "foobar"`) + inmemHCL,
+			`unknown role "foobar"`,
+			1,
+			[]string{"-test-server-config"},
+		},
 		{
 			"environment_variables_logged",
 			testBaseHCL(t, "") + inmemHCL,
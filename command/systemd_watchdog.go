@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	systemd "github.com/coreos/go-systemd/daemon"
+	"github.com/hashicorp/go-hclog"
+)
+
+// maxStateTransitionWatchdogIntervals is how many watchdog intervals a state
+// transition may take before keepalives stop being sent during it.
+const maxStateTransitionWatchdogIntervals = 10
+
+// watchdogCheck is a health check run before each systemd watchdog keepalive.
+// It's given the watchdog interval.
+type watchdogCheck func(ctx context.Context, interval time.Duration) error
+
+// startSystemdWatchdog keeps the systemd watchdog fed when the unit is
+// configured with WatchdogSec, so that systemd restarts the process if it
+// stops responding. Keepalives are sent at half the watchdog interval, and
+// only once check, if not nil, succeeds within a quarter of the interval. The
+// returned function stops sending them, and is a no-op if the watchdog is not
+// enabled.
+func startSystemdWatchdog(logger hclog.Logger, check watchdogCheck) func() {
+	interval, err := systemd.SdWatchdogEnabled(false)
+	if err != nil {
+		logger.Warn("error reading systemd watchdog configuration", "error", err)
+		return func() {}
+	}
+	if interval == 0 {
+		return func() {}
+	}
+
+	logger.Debug("sending systemd watchdog keepalives", "interval", interval/2)
+
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if check != nil {
+					ctx, cancel := context.WithTimeout(context.Background(), interval/4)
+					err := check(ctx, interval)
+					cancel()
+					if err != nil {
+						logger.Warn("skipping systemd watchdog keepalive, health check failed", "error", err)
+						continue
+					}
+				}
+				if _, err := systemd.SdNotify(false, systemd.SdNotifyWatchdog); err != nil {
+					logger.Error("error notifying systemd watchdog", "error", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stopCh)
+		<-doneCh
+	}
+}
+
+// lockCheck returns a watchdog health check that succeeds once l can be
+// acquired. At most one acquisition is outstanding at a time, so a lock that
+// stays held doesn't accumulate goroutines. The check must not be called
+// concurrently.
+func lockCheck(l sync.Locker) watchdogCheck {
+	var pending chan struct{}
+	return func(ctx context.Context, _ time.Duration) error {
+		if pending == nil {
+			acquired := make(chan struct{})
+			go func() {
+				l.Lock()
+				l.Unlock()
+				close(acquired)
+			}()
+			pending = acquired
+		}
+
+		select {
+		case <-pending:
+			pending = nil
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("timed out acquiring lock: %w", ctx.Err())
+		}
+	}
+}
+
+// skipCheckDuringTransition returns a watchdog health check that succeeds
+// without running check while a state transition, which started at the time
+// returned by started, is in progress. Once the transition has taken more than
+// maxStateTransitionWatchdogIntervals watchdog intervals it's considered
+// stuck, and the check fails until it completes.
+func skipCheckDuringTransition(started func() time.Time, check watchdogCheck) watchdogCheck {
+	return func(ctx context.Context, interval time.Duration) error {
+		start := started()
+		if start.IsZero() {
+			return check(ctx, interval)
+		}
+		if elapsed := time.Since(start); elapsed > maxStateTransitionWatchdogIntervals*interval {
+			return fmt.Errorf("state transition in progress for %s", elapsed.Round(time.Millisecond))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vault/helper/locking"
+)
+
+// TestStartSystemdWatchdog tests that keepalives are sent to the notify socket
+// when the watchdog is enabled, and that nothing happens otherwise.
+func TestStartSystemdWatchdog(t *testing.T) {
+	dir, err := os.MkdirTemp("", "notify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	addr := &net.UnixAddr{Name: filepath.Join(dir, "notify.sock"), Net: "unixgram"}
+	conn, err := net.ListenUnixgram("unixgram", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	t.Setenv("NOTIFY_SOCKET", addr.Name)
+	t.Setenv("WATCHDOG_PID", "")
+
+	// Not enabled
+	t.Setenv("WATCHDOG_USEC", "")
+	startSystemdWatchdog(hclog.NewNullLogger(), nil)()
+
+	t.Setenv("WATCHDOG_USEC", "20000")
+	stop := startSystemdWatchdog(hclog.NewNullLogger(), nil)
+	defer stop()
+
+	buf := make([]byte, 64)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "WATCHDOG=1" {
+		t.Fatalf("unexpected notification %q", got)
+	}
+}
+
+// TestStartSystemdWatchdog_HealthCheck tests that keepalives are only sent
+// while the health check succeeds.
+func TestStartSystemdWatchdog_HealthCheck(t *testing.T) {
+	dir := t.TempDir()
+	addr := &net.UnixAddr{Name: filepath.Join(dir, "notify.sock"), Net: "unixgram"}
+	conn, err := net.ListenUnixgram("unixgram", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	t.Setenv("NOTIFY_SOCKET", addr.Name)
+	t.Setenv("WATCHDOG_PID", "")
+	t.Setenv("WATCHDOG_USEC", "20000")
+
+	var l sync.Mutex
+	l.Lock()
+	stop := startSystemdWatchdog(hclog.NewNullLogger(), lockCheck(&l))
+	defer stop()
+
+	buf := make([]byte, 64)
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if n, err := conn.Read(buf); err == nil {
+		t.Fatalf("unexpected notification %q while the lock is held", buf[:n])
+	}
+
+	l.Unlock()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "WATCHDOG=1" {
+		t.Fatalf("unexpected notification %q", got)
+	}
+}
+
+// TestStartSystemdWatchdog_StateTransition tests that keepalives are sent
+// without the lock check while the lock is held for a state transition, such
+// as a long unseal, but stop once it has been held for too long.
+func TestStartSystemdWatchdog_StateTransition(t *testing.T) {
+	dir := t.TempDir()
+	addr := &net.UnixAddr{Name: filepath.Join(dir, "notify.sock"), Net: "unixgram"}
+	conn, err := net.ListenUnixgram("unixgram", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	interval := 50 * time.Millisecond
+	t.Setenv("NOTIFY_SOCKET", addr.Name)
+	t.Setenv("WATCHDOG_PID", "")
+	t.Setenv("WATCHDOG_USEC", strconv.FormatInt(interval.Microseconds(), 10))
+
+	l := &locking.SyncRWMutex{}
+	l.Lock()
+	locked := true
+	defer func() {
+		if locked {
+			l.Unlock()
+		}
+	}()
+	stop := startSystemdWatchdog(hclog.NewNullLogger(), skipCheckDuringTransition(l.WriteLockedSince, lockCheck(l.RLocker())))
+	defer stop()
+
+	buf := make([]byte, 64)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "WATCHDOG=1" {
+		t.Fatalf("unexpected notification %q", got)
+	}
+
+	// Hold the lock indefinitely: keepalives stop once the transition has
+	// taken too long. Drain those sent until then.
+	time.Sleep(time.Until(l.WriteLockedSince().Add((maxStateTransitionWatchdogIntervals + 1) * interval)))
+	for {
+		conn.SetReadDeadline(time.Now().Add(time.Millisecond))
+		if _, err := conn.Read(buf); err != nil {
+			break
+		}
+	}
+	conn.SetReadDeadline(time.Now().Add(3 * interval))
+	if n, err := conn.Read(buf); err == nil {
+		t.Fatalf("unexpected notification %q while the lock has been held too long", buf[:n])
+	}
+
+	// Keepalives resume once the transition completes
+	l.Unlock()
+	locked = false
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err = conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "WATCHDOG=1" {
+		t.Fatalf("unexpected notification %q", got)
+	}
+}
@@ -37,7 +37,7 @@ func ParseDetectDeadlockConfigParameter(detectDeadlockConfigParameter string) []
 // sync.Mutex is returned.
 func CreateConfigurableRWMutex(deadlockDetectionLocks []string, identifier string) RWMutex {
 	if slices.Contains(deadlockDetectionLocks, strings.ToLower(identifier)) {
-		return &DeadlockRWMutex{}
+		return &DeadlockRWMutex{Name: identifier}
 	}
 
 	return &SyncRWMutex{}
@@ -5,7 +5,10 @@ package locking
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/armon/go-metrics"
 	"github.com/sasha-s/go-deadlock"
 )
 
@@ -22,6 +25,7 @@ type RWMutex interface {
 	RLocker() sync.Locker
 	RUnlock()
 	Unlock()
+	WriteLockedSince() time.Time
 }
 
 // DeadlockMutex (used when requested via config option `detact_deadlocks`),
@@ -33,11 +37,54 @@ type DeadlockMutex struct {
 	deadlock.Mutex
 }
 
-// DeadlockRWMutex is the RW version of DeadlockMutex.
+// DeadlockRWMutex is the RW version of DeadlockMutex. It also reports how long
+// callers wait to acquire the lock as the vault.core.lock.wait metric, labeled
+// with Name, to help pinpoint contention.
 type DeadlockRWMutex struct {
 	deadlock.RWMutex
+	Name string
+
+	writeLockedAt atomic.Int64
+}
+
+func (m *DeadlockRWMutex) Lock() {
+	defer m.measureWait("write", time.Now())
+	m.RWMutex.Lock()
+	m.writeLockedAt.Store(time.Now().UnixNano())
+}
+
+func (m *DeadlockRWMutex) Unlock() {
+	m.writeLockedAt.Store(0)
+	m.RWMutex.Unlock()
+}
+
+// WriteLockedSince returns when the write lock currently held was acquired,
+// or the zero time if it isn't held.
+func (m *DeadlockRWMutex) WriteLockedSince() time.Time {
+	return lockedSince(m.writeLockedAt.Load())
+}
+
+func (m *DeadlockRWMutex) RLock() {
+	defer m.measureWait("read", time.Now())
+	m.RWMutex.RLock()
+}
+
+func (m *DeadlockRWMutex) RLocker() sync.Locker {
+	return (*deadlockRLocker)(m)
+}
+
+func (m *DeadlockRWMutex) measureWait(mode string, start time.Time) {
+	metrics.MeasureSinceWithLabels([]string{"core", "lock", "wait"}, start, []metrics.Label{
+		{Name: "lock", Value: m.Name},
+		{Name: "mode", Value: mode},
+	})
 }
 
+type deadlockRLocker DeadlockRWMutex
+
+func (r *deadlockRLocker) Lock()   { (*DeadlockRWMutex)(r).RLock() }
+func (r *deadlockRLocker) Unlock() { (*DeadlockRWMutex)(r).RUnlock() }
+
 // Regular sync/mutex.
 type SyncMutex struct {
 	sync.Mutex
@@ -46,4 +93,29 @@ type SyncMutex struct {
 // DeadlockRWMutex is the RW version of SyncMutex.
 type SyncRWMutex struct {
 	sync.RWMutex
+
+	writeLockedAt atomic.Int64
+}
+
+func (m *SyncRWMutex) Lock() {
+	m.RWMutex.Lock()
+	m.writeLockedAt.Store(time.Now().UnixNano())
+}
+
+func (m *SyncRWMutex) Unlock() {
+	m.writeLockedAt.Store(0)
+	m.RWMutex.Unlock()
+}
+
+// WriteLockedSince returns when the write lock currently held was acquired,
+// or the zero time if it isn't held.
+func (m *SyncRWMutex) WriteLockedSince() time.Time {
+	return lockedSince(m.writeLockedAt.Load())
+}
+
+func lockedSince(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
 }
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package locking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+// TestDeadlockRWMutex_WaitMetrics verifies that acquiring a DeadlockRWMutex
+// reports the wait time, labeled with the lock's name and mode. This test
+// cannot be run in parallel, because we are using the global metrics instance.
+func TestDeadlockRWMutex_WaitMetrics(t *testing.T) {
+	inMemSink := metrics.NewInmemSink(10000*time.Hour, 10000*time.Hour)
+	_, err := metrics.NewGlobal(metrics.DefaultConfig("vault"), inMemSink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := CreateConfigurableRWMutex([]string{"foo"}, "foo")
+	m.Lock()
+	m.Unlock()
+	m.RLock()
+	m.RUnlock()
+	l := m.RLocker()
+	l.Lock()
+	l.Unlock()
+
+	modes := map[string]int{}
+	for _, interval := range inMemSink.Data() {
+		for _, sample := range interval.Samples {
+			if sample.Name != "vault.core.lock.wait" {
+				continue
+			}
+			labels := map[string]string{}
+			for _, label := range sample.Labels {
+				labels[label.Name] = label.Value
+			}
+			if labels["lock"] != "foo" {
+				t.Fatalf("unexpected lock label: %q", labels["lock"])
+			}
+			modes[labels["mode"]] += sample.Count
+		}
+	}
+	if modes["write"] != 1 || modes["read"] != 2 {
+		t.Fatalf("unexpected wait samples: %v", modes)
+	}
+}
+
+// TestRWMutex_WriteLockedSince verifies that both RWMutex implementations
+// report when the write lock was acquired, and not a read lock.
+func TestRWMutex_WriteLockedSince(t *testing.T) {
+	for name, m := range map[string]RWMutex{
+		"sync":     &SyncRWMutex{},
+		"deadlock": &DeadlockRWMutex{Name: "foo"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if !m.WriteLockedSince().IsZero() {
+				t.Fatal("expected the write lock not to be held")
+			}
+			m.RLock()
+			if !m.WriteLockedSince().IsZero() {
+				t.Fatal("expected a read lock not to count as the write lock")
+			}
+			m.RUnlock()
+
+			before := time.Now()
+			m.Lock()
+			if since := m.WriteLockedSince(); since.Before(before) || since.After(time.Now()) {
+				t.Fatalf("unexpected write lock time %v", since)
+			}
+			m.Unlock()
+			if !m.WriteLockedSince().IsZero() {
+				t.Fatal("expected the write lock to be released")
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package tracing wires Vault's request handling into OpenTelemetry. Until
+// Setup is called the global tracer provider is a no-op, so the spans started
+// through this package cost next to nothing when trace export is disabled.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/hashicorp/vault"
+
+// Config holds the settings needed to export traces over OTLP/HTTP.
+type Config struct {
+	// Endpoint is the host and optional port of the OTLP/HTTP collector.
+	Endpoint string
+	// Insecure disables TLS when talking to the collector.
+	Insecure bool
+	// SampleRatio is the fraction of new root traces that are sampled.
+	// Requests carrying a sampled traceparent header are always traced.
+	SampleRatio float64
+	// ServiceName and ServiceVersion identify this process in exported spans.
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Setup installs a global tracer provider that exports spans to the configured
+// OTLP collector, along with a W3C trace context propagator so incoming
+// traceparent headers are honored. The returned function flushes any buffered
+// spans and must be called on shutdown.
+func Setup(ctx context.Context, conf *Config) (func(context.Context) error, error) {
+	if conf.Endpoint == "" {
+		return nil, fmt.Errorf("an OTLP endpoint is required to export traces")
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(conf.Endpoint)}
+	if conf.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP trace exporter: %w", err)
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(conf.ServiceName),
+		semconv.ServiceVersion(conf.ServiceVersion),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Extract returns a copy of ctx carrying any trace context found in the given
+// HTTP headers.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// StartSpan starts a span named name as a child of any span already in ctx.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err on span, if non-nil, and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
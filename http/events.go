@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/vault"
+	"github.com/patrickmn/go-cache"
+	"github.com/ryanuber/go-glob"
+	"google.golang.org/protobuf/proto"
+	"nhooyr.io/websocket"
+)
+
+const (
+	// eventsSubscribePrefix is the path, relative to the request namespace,
+	// under which event types are subscribed to.
+	eventsSubscribePrefix = "sys/events/subscribe/"
+
+	// eventsPermissionCacheTTL is how long the result of checking whether a
+	// subscriber may see events for a given path is reused before the token's
+	// policies are evaluated again. Cached results are dropped as soon as any
+	// policy changes, so this only delays noticing changes to the token's or
+	// its entity's policy assignments.
+	eventsPermissionCacheTTL = 30 * time.Second
+)
+
+// eventsTokenCheckInterval is how often the subscribing token is checked to
+// still be valid. The subscription is closed once it isn't. It's a var to
+// allow for testing.
+var eventsTokenCheckInterval = 10 * time.Second
+
+// eventSubscriber writes the events matching a single subscription to a
+// websocket connection, dropping those the subscribing token isn't allowed to
+// see.
+type eventSubscriber struct {
+	core        *vault.Core
+	logger      hclog.Logger
+	conn        *websocket.Conn
+	clientToken string
+	json        bool
+
+	// permissions caches whether events for a given namespace, data path and
+	// event type may be sent to the subscriber
+	permissions *cache.Cache
+}
+
+// handleEventsSubscribe returns a handler that upgrades the request to a
+// websocket and streams the events matching the event type pattern in the
+// request path until either side closes the connection.
+func handleEventsSubscribe(core *vault.Core, req *logical.Request) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := core.Logger().Named("events-subscribe")
+
+		ns, err := namespace.FromContext(ctx)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		pattern := strings.TrimPrefix(req.Path, eventsSubscribePrefix)
+		if pattern == "" || pattern == req.Path {
+			respondError(w, http.StatusBadRequest, errors.New("missing event type pattern"))
+			return
+		}
+
+		// The token needs read on the subscribe path itself; access to the
+		// individual events is checked as they are received.
+		if _, _, err := core.CheckToken(ctx, req, false); err != nil {
+			// Keep the underlying error, as clients such as Vault Proxy look
+			// for an invalid token to know they should re-authenticate.
+			if errors.Is(err, logical.ErrPermissionDenied) || errors.Is(err, logical.ErrInvalidToken) {
+				respondError(w, http.StatusForbidden, err)
+				return
+			}
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		query := r.URL.Query()
+		jsonOutput := false
+		if raw := query.Get("json"); raw != "" {
+			jsonOutput, err = strconv.ParseBool(raw)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, fmt.Errorf("invalid value for json: %w", err))
+				return
+			}
+		}
+
+		// Namespaces are given relative to the request namespace, which is
+		// always included.
+		namespacePatterns := []string{strings.Trim(ns.Path, "/")}
+		for _, p := range query["namespaces"] {
+			p = strings.Trim(p, "/")
+			if p == "" {
+				continue
+			}
+			namespacePatterns = append(namespacePatterns, strings.Trim(ns.Path+p, "/"))
+		}
+
+		events := core.Events()
+		if events == nil {
+			respondError(w, http.StatusServiceUnavailable, errors.New("events are not available"))
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			logger.Info("failed to accept websocket connection", "error", err)
+			return
+		}
+		defer conn.Close(websocket.StatusInternalError, "")
+
+		sub := &eventSubscriber{
+			core:        core,
+			logger:      logger,
+			conn:        conn,
+			clientToken: req.ClientToken,
+			json:        jsonOutput,
+			permissions: cache.New(eventsPermissionCacheTTL, 2*eventsPermissionCacheTTL),
+		}
+
+		// The client isn't expected to send anything, but reading is needed to
+		// notice it closing the connection.
+		ctx = conn.CloseRead(ctx)
+
+		status, err := sub.serve(ctx, namespacePatterns, pattern, query.Get("filter"))
+		if err != nil {
+			logger.Debug("event subscription closed", "error", err)
+			conn.Close(status, err.Error())
+			return
+		}
+		conn.Close(status, "")
+	})
+}
+
+// serve subscribes to the events matching the given patterns and filter, and
+// writes them to the connection until ctx is done or a write fails. It returns
+// the status to close the connection with.
+func (s *eventSubscriber) serve(ctx context.Context, namespacePatterns []string, pattern, bexprFilter string) (websocket.StatusCode, error) {
+	ch, cancel, err := s.core.Events().SubscribeMultipleNamespaces(ctx, namespacePatterns, pattern, bexprFilter)
+	if err != nil {
+		return websocket.StatusInternalError, err
+	}
+	defer cancel()
+
+	ticker := time.NewTicker(eventsTokenCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return websocket.StatusNormalClosure, nil
+		case <-ticker.C:
+			te, err := s.core.LookupToken(ctx, s.clientToken)
+			if err != nil {
+				s.logger.Debug("failed to check subscription token", "error", err)
+				continue
+			}
+			if te == nil {
+				return websocket.StatusPolicyViolation, logical.ErrInvalidToken
+			}
+		case message, ok := <-ch:
+			if !ok {
+				return websocket.StatusNormalClosure, nil
+			}
+			if err := s.write(ctx, message); err != nil {
+				return websocket.StatusInternalError, err
+			}
+		}
+	}
+}
+
+// write sends a single event to the subscriber, if it's allowed to see it.
+func (s *eventSubscriber) write(ctx context.Context, message *eventlogger.Event) error {
+	event, ok := message.Payload.(*logical.EventReceived)
+	if !ok {
+		return nil
+	}
+	if !s.allowed(ctx, event) {
+		return nil
+	}
+
+	var messageType websocket.MessageType
+	var b []byte
+	if s.json {
+		messageType = websocket.MessageText
+		b, ok = message.Format("cloudevents-json")
+		if !ok {
+			s.logger.Warn("failed to get cloudevents JSON format for event")
+			return nil
+		}
+	} else {
+		var err error
+		messageType = websocket.MessageBinary
+		b, err = proto.Marshal(event)
+		if err != nil {
+			s.logger.Warn("failed to encode event", "error", err)
+			return nil
+		}
+	}
+
+	return s.conn.Write(ctx, messageType, b)
+}
+
+// allowed reports whether the subscriber may see the event. Events about a
+// data path require list and subscribe on that path, and the event type must
+// be one of the token's subscribe_event_types for it. Events without a data
+// path are only sent to root tokens.
+func (s *eventSubscriber) allowed(ctx context.Context, event *logical.EventReceived) bool {
+	var dataPath string
+	if metadata := event.GetEvent().GetMetadata(); metadata != nil {
+		if v, ok := metadata.GetFields()[logical.EventMetadataDataPath]; ok {
+			dataPath = v.GetStringValue()
+		}
+	}
+	fullPath := event.Namespace + strings.TrimPrefix(dataPath, "/")
+
+	// Keying on the ACL generation drops cached decisions once any policy
+	// changes
+	key := fmt.Sprintf("%d|%s|%s|%s", s.core.ACLGeneration(), event.Namespace, dataPath, event.EventType)
+	if cached, ok := s.permissions.Get(key); ok {
+		return cached.(bool)
+	}
+
+	// Paths are checked relative to the root namespace, since the event may
+	// come from a child namespace of the request namespace.
+	capabilities, eventTypes, err := s.core.CapabilitiesAndSubscribeEventTypes(namespace.RootContext(ctx), s.clientToken, fullPath)
+	if err != nil {
+		s.logger.Debug("failed to check event permissions", "path", fullPath, "error", err)
+		return false
+	}
+
+	allowed := eventAllowed(capabilities, eventTypes, dataPath, event.EventType)
+	s.permissions.SetDefault(key, allowed)
+	return allowed
+}
+
+// eventAllowed decides whether an event may be sent given the capabilities
+// and subscribe_event_types the token has on the event's data path.
+func eventAllowed(capabilities, eventTypes []string, dataPath, eventType string) bool {
+	var root, list, subscribe bool
+	for _, c := range capabilities {
+		switch c {
+		case vault.RootCapability:
+			root = true
+		case vault.ListCapability:
+			list = true
+		case vault.SubscribeCapability:
+			subscribe = true
+		}
+	}
+	if root {
+		return true
+	}
+	if dataPath == "" || !list || !subscribe {
+		return false
+	}
+
+	for _, t := range eventTypes {
+		if glob.Glob(t, eventType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/vault"
+	"nhooyr.io/websocket"
+)
+
+func testEventsSubscribe(t *testing.T, addr, token, pattern string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+
+	url := strings.Replace(addr, "http", "ws", 1) + "/v1/sys/events/subscribe/" + pattern + "?json=true"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return websocket.Dial(ctx, url, &websocket.DialOptions{
+		HTTPHeader: http.Header{"X-Vault-Token": []string{token}},
+	})
+}
+
+func TestEventsSubscribe(t *testing.T) {
+	core, _, token := vault.TestCoreUnsealed(t)
+	ln, addr := TestServer(t, core)
+	defer ln.Close()
+
+	conn, _, err := testEventsSubscribe(t, addr, token, "mount/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	resp := testHttpPost(t, token, addr+"/v1/sys/mounts/foo", map[string]interface{}{
+		"type": "kv",
+	})
+	testResponseStatus(t, resp, 204)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	msgType, msg, err := conn.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msgType != websocket.MessageText {
+		t.Fatalf("expected text message, got %v", msgType)
+	}
+
+	var event struct {
+		Type string `json:"type"`
+		Data struct {
+			EventType string `json:"event_type"`
+			Event     struct {
+				Metadata map[string]interface{} `json:"metadata"`
+			} `json:"event"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Data.EventType != "mount/enable" {
+		t.Fatalf("bad event type: %q", event.Data.EventType)
+	}
+	if v := event.Data.Event.Metadata["data_path"]; v != "sys/mounts/foo/" {
+		t.Fatalf("bad data path: %v", v)
+	}
+}
+
+func TestEventsSubscribe_permissionDenied(t *testing.T) {
+	core, _, _ := vault.TestCoreUnsealed(t)
+	ln, addr := TestServer(t, core)
+	defer ln.Close()
+
+	_, resp, err := testEventsSubscribe(t, addr, "bogus", "mount/*")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403, got %#v", resp)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), logical.ErrInvalidToken.Error()) {
+		t.Fatalf("expected an invalid token error, got %q", body)
+	}
+}
+
+// TestEventsSubscribe_tokenRevoked tests that a subscription is closed once
+// its token is revoked.
+func TestEventsSubscribe_tokenRevoked(t *testing.T) {
+	oldInterval := eventsTokenCheckInterval
+	eventsTokenCheckInterval = 10 * time.Millisecond
+	t.Cleanup(func() { eventsTokenCheckInterval = oldInterval })
+
+	core, _, token := vault.TestCoreUnsealed(t)
+	ln, addr := TestServer(t, core)
+	defer ln.Close()
+
+	resp := testHttpPost(t, token, addr+"/v1/auth/token/create", map[string]interface{}{
+		"policies": []string{"root"},
+	})
+	var auth map[string]interface{}
+	testResponseStatus(t, resp, 200)
+	testResponseBody(t, resp, &auth)
+	childToken := auth["auth"].(map[string]interface{})["client_token"].(string)
+
+	conn, _, err := testEventsSubscribe(t, addr, childToken, "mount/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	resp = testHttpPost(t, token, addr+"/v1/auth/token/revoke", map[string]interface{}{
+		"token": childToken,
+	})
+	testResponseStatus(t, resp, 204)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, _, err = conn.Read(ctx)
+	if status := websocket.CloseStatus(err); status != websocket.StatusPolicyViolation {
+		t.Fatalf("expected the subscription to be closed with a policy violation, got: %v", err)
+	}
+}
+
+func TestEventAllowed(t *testing.T) {
+	cases := map[string]struct {
+		capabilities []string
+		eventTypes   []string
+		dataPath     string
+		allowed      bool
+	}{
+		"root":                 {[]string{"root"}, nil, "", true},
+		"no data path":         {[]string{"list", "subscribe"}, []string{"*"}, "", false},
+		"list and subscribe":   {[]string{"list", "subscribe"}, []string{"kv*"}, "secret/foo", true},
+		"missing list":         {[]string{"subscribe"}, []string{"*"}, "secret/foo", false},
+		"missing subscribe":    {[]string{"list"}, []string{"*"}, "secret/foo", false},
+		"no event types":       {[]string{"list", "subscribe"}, nil, "secret/foo", false},
+		"unmatched event type": {[]string{"list", "subscribe"}, []string{"mount/*"}, "secret/foo", false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if allowed := eventAllowed(tc.capabilities, tc.eventTypes, tc.dataPath, "kv-v2/data-write"); allowed != tc.allowed {
+				t.Fatalf("expected %t, got %t", tc.allowed, allowed)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/hashicorp/go-sockaddr"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/helper/tracing"
 	"github.com/hashicorp/vault/http/priority"
 	"github.com/hashicorp/vault/internalshared/configutil"
 	"github.com/hashicorp/vault/limits"
@@ -40,6 +41,7 @@ import (
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/hashicorp/vault/vault"
 	gziphandler "github.com/klauspost/compress/gzhttp"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -159,6 +161,13 @@ func handler(props *vault.HandlerProperties) http.Handler {
 	}
 
 	switch {
+	case props.ListenerConfig != nil && props.ListenerConfig.Role == "metrics_only":
+		// Metrics only listeners serve nothing but the metrics endpoint
+		if props.ListenerConfig.Telemetry.UnauthenticatedMetricsAccess {
+			mux.Handle("/v1/sys/metrics", handleMetricsUnauthenticated(core))
+		} else {
+			mux.Handle("/v1/sys/metrics", handleLogicalNoForward(core, chrootNamespace))
+		}
 	case props.RecoveryMode:
 		raw := vault.NewRawBackend(core)
 		strategy := vault.GenerateRecoveryTokenStrategy(props.RecoveryToken)
@@ -504,7 +513,15 @@ func wrapGenericHandler(core *vault.Core, h http.Handler, props *vault.HandlerPr
 			nw.Header().Set(consts.NamespaceHeaderName, ns)
 		}
 
-		h.ServeHTTP(nw, r)
+		// Continue any trace started by the client so the request shows up as
+		// part of its caller's trace
+		spanCtx, span := tracing.StartSpan(tracing.Extract(r.Context(), r.Header), "http.request",
+			attribute.String("http.method", r.Method),
+			attribute.String("http.path", r.URL.Path),
+		)
+		h.ServeHTTP(nw, r.WithContext(spanCtx))
+		span.SetAttributes(attribute.Int("http.status_code", nw.StatusCode))
+		span.End()
 
 		cancelFunc()
 	}
@@ -1115,7 +1132,7 @@ func respondStandby(core *vault.Core, w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if err == vault.ErrHANotEnabled {
 			// Standalone node, serve 503
-			err = errors.New("node is not active")
+			err = logical.WithErrorCode(errors.New("node is not active"), logical.ErrorCodeStandby)
 			respondError(w, http.StatusServiceUnavailable, err)
 			return
 		}
@@ -1126,7 +1143,7 @@ func respondStandby(core *vault.Core, w http.ResponseWriter, r *http.Request) {
 
 	// If there is no leader, generate a 503 error
 	if redirectAddr == "" {
-		err = errors.New("no active Vault instance found")
+		err = logical.WithErrorCode(errors.New("no active Vault instance found"), logical.ErrorCodeStandby)
 		respondError(w, http.StatusServiceUnavailable, err)
 		return
 	}
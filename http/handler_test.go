@@ -667,6 +667,38 @@ func TestHandler_sealed(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 	testResponseStatus(t, resp, 503)
+
+	var actual map[string]interface{}
+	testResponseBody(t, resp, &actual)
+	if actual["error_code"] != logical.ErrorCodeSealed {
+		t.Fatalf("bad error code: %#v", actual)
+	}
+}
+
+func TestHandler_errorCode(t *testing.T) {
+	core, _, _ := vault.TestCoreUnsealed(t)
+	ln, addr := TestServer(t, core)
+	defer ln.Close()
+
+	resp := testHttpGet(t, "", addr+"/v1/secret/foo")
+	testResponseStatus(t, resp, 403)
+
+	var actual map[string]interface{}
+	testResponseBody(t, resp, &actual)
+	if actual["error_code"] != logical.ErrorCodePermissionDenied {
+		t.Fatalf("bad error code: %#v", actual)
+	}
+
+	// Errors without a known code don't report one
+	w := httptest.NewRecorder()
+	respondError(w, 500, errors.New("test Error"))
+	actual = nil
+	if err := json.NewDecoder(w.Body).Decode(&actual); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := actual["error_code"]; ok {
+		t.Fatalf("unexpected error code: %#v", actual)
+	}
 }
 
 func TestHandler_ui_default(t *testing.T) {
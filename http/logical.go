@@ -368,10 +368,11 @@ func handleLogicalInternal(core *vault.Core, injectDataIntoTopLevel bool, noForw
 		}
 		if websocketPaths.HasPath(trimmedPath) {
 			handler := entHandleEventsSubscribe(core, req)
-			if handler != nil {
-				handler.ServeHTTP(w, r)
-				return
+			if handler == nil {
+				handler = handleEventsSubscribe(core, req)
 			}
+			handler.ServeHTTP(w, r)
+			return
 		}
 		handler := handleEntPaths(nsPath, core, r)
 		if handler != nil {
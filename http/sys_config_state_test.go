@@ -160,11 +160,13 @@ func TestSysConfigState_Sanitized(t *testing.T) {
 				"log_format":                          "",
 				"log_level":                           "",
 				"max_lease_ttl":                       json.Number("0"),
+				"mount_deletion_grace_period":         json.Number("0"),
 				"pid_file":                            "",
 				"plugin_directory":                    "",
 				"plugin_tmpdir":                       "",
 				"plugin_file_uid":                     json.Number("0"),
 				"plugin_file_permissions":             json.Number("0"),
+				"plugin_download_pgp_keys":            nil,
 				"enable_response_header_hostname":     false,
 				"enable_response_header_raft_node_id": false,
 				"log_requests_level":                  "",
@@ -177,6 +179,7 @@ func TestSysConfigState_Sanitized(t *testing.T) {
 				"storage":                       tc.expectedStorageOutput,
 				"administrative_namespace_path": "",
 				"imprecise_lease_role_tracking": false,
+				"barrier_prefix_key_derivation": false,
 			}
 
 			if tc.expectedHAStorageOutput != nil {
@@ -61,6 +61,39 @@ func TestSysMetricsUnauthenticated(t *testing.T) {
 	testResponseStatus(t, resp, 200)
 }
 
+// TestSysMetrics_MetricsOnlyListener verifies that a metrics only listener
+// serves the metrics endpoint and nothing else.
+func TestSysMetrics_MetricsOnlyListener(t *testing.T) {
+	inm := metrics.NewInmemSink(10*time.Second, time.Minute)
+	metrics.DefaultInmemSignal(inm)
+	conf := &vault.CoreConfig{
+		BuiltinRegistry: corehelpers.NewMockBuiltinRegistry(),
+		MetricsHelper:   metricsutil.NewMetricsHelper(inm, true),
+	}
+	core, _, token := vault.TestCoreUnsealedWithConfig(t, conf)
+
+	ln, addr := TestListener(t)
+	props := &vault.HandlerProperties{
+		Core: core,
+		ListenerConfig: &configutil.Listener{
+			Role: "metrics_only",
+		},
+	}
+	TestServerWithListenerAndProperties(t, ln, addr, core, props)
+	defer ln.Close()
+
+	resp := testHttpGet(t, "", addr+"/v1/sys/metrics")
+	testResponseStatus(t, resp, 403)
+	resp = testHttpGet(t, token, addr+"/v1/sys/metrics")
+	testResponseStatus(t, resp, 200)
+
+	// Nothing else is served
+	resp = testHttpGet(t, token, addr+"/v1/sys/mounts")
+	testResponseStatus(t, resp, 404)
+	resp = testHttpGet(t, "", addr+"/v1/sys/health")
+	testResponseStatus(t, resp, 404)
+}
+
 func TestSysPProfUnauthenticated(t *testing.T) {
 	conf := &vault.CoreConfig{}
 	core, _, token := vault.TestCoreUnsealedWithConfig(t, conf)
@@ -100,6 +100,12 @@ func TestHTTP_Wrapping(t *testing.T) {
 	// Test lookup
 	//
 
+	rootLookup, err := client.Auth().Token().LookupSelf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootAccessor := rootLookup.Data["accessor"].(string)
+
 	// Create a wrapping token
 	secret, err := client.Logical().Read("secret/foo")
 	if err != nil {
@@ -128,6 +134,9 @@ func TestHTTP_Wrapping(t *testing.T) {
 		if secret.Data["creation_time"].(string) != wrapInfo.CreationTime.Format(time.RFC3339Nano) {
 			t.Fatalf("mismatched creation times: %q vs %q", secret.Data["creation_time"].(string), wrapInfo.CreationTime.Format(time.RFC3339Nano))
 		}
+		if secret.Data["creator_accessor"] != rootAccessor {
+			t.Fatalf("mismatched creator accessors: %q vs %q", secret.Data["creator_accessor"], rootAccessor)
+		}
 	}
 
 	//
@@ -353,8 +362,19 @@ func TestHTTP_Wrapping(t *testing.T) {
 		t.Fatal("expected err")
 	}
 
-	// Attempt unwrapping the rewrapped token
+	// The rewrapped token keeps the original creator
 	wrapToken := secret.WrapInfo.Token
+	secret, err = client.Logical().Write("sys/wrapping/lookup", map[string]interface{}{
+		"token": wrapToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret.Data["creator_accessor"] != rootAccessor {
+		t.Fatalf("mismatched creator accessors after rewrap: %q vs %q", secret.Data["creator_accessor"], rootAccessor)
+	}
+
+	// Attempt unwrapping the rewrapped token
 	secret, err = client.Logical().Unwrap(wrapToken)
 	if err != nil {
 		t.Fatal(err)
@@ -280,6 +280,9 @@ func (c *SharedConfig) Sanitized() map[string]interface{} {
 			"num_lease_metrics_buckets":              c.Telemetry.NumLeaseMetricsTimeBuckets,
 			"add_lease_metrics_namespace_labels":     c.Telemetry.LeaseMetricsNameSpaceLabels,
 			"add_mount_point_rollback_metrics":       c.Telemetry.RollbackMetricsIncludeMountPoint,
+			"otlp_traces_endpoint":                   c.Telemetry.OTLPTracesEndpoint,
+			"otlp_traces_insecure":                   c.Telemetry.OTLPTracesInsecure,
+			"otlp_traces_sample_ratio":               c.Telemetry.OTLPTracesSampleRatio,
 		}
 		result["telemetry"] = sanitizedTelemetry
 	}
@@ -166,6 +166,16 @@ type Telemetry struct {
 	// Whether or not telemetry should include the mount point in the rollback
 	// metrics
 	RollbackMetricsIncludeMountPoint bool `hcl:"add_mount_point_rollback_metrics"`
+
+	// OpenTelemetry:
+	// OTLPTracesEndpoint is the host and optional port of an OTLP/HTTP
+	// collector. If provided, request traces will be exported to it.
+	OTLPTracesEndpoint string `hcl:"otlp_traces_endpoint"`
+	// OTLPTracesInsecure disables TLS when exporting traces.
+	OTLPTracesInsecure bool `hcl:"otlp_traces_insecure"`
+	// OTLPTracesSampleRatio is the fraction of root traces that are sampled.
+	// Default: 1.0
+	OTLPTracesSampleRatio *float64 `hcl:"otlp_traces_sample_ratio"`
 }
 
 func (t *Telemetry) Validate(source string) []ConfigError {
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	aero "github.com/aerospike/aerospike-client-go/v5"
+	"github.com/armon/go-metrics"
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/hashicorp/vault/sdk/physical"
@@ -140,6 +141,8 @@ func (a *AerospikeBackend) key(userKey string) (*aero.Key, error) {
 
 // Put is used to insert or update an entry.
 func (a *AerospikeBackend) Put(_ context.Context, entry *physical.Entry) error {
+	defer metrics.MeasureSince([]string{"aerospike", "put"}, time.Now())
+
 	aeroKey, err := a.key(entry.Key)
 	if err != nil {
 		return err
@@ -158,6 +161,8 @@ func (a *AerospikeBackend) Put(_ context.Context, entry *physical.Entry) error {
 
 // Get is used to fetch an entry.
 func (a *AerospikeBackend) Get(_ context.Context, key string) (*physical.Entry, error) {
+	defer metrics.MeasureSince([]string{"aerospike", "get"}, time.Now())
+
 	aeroKey, err := a.key(key)
 	if err != nil {
 		return nil, err
@@ -184,6 +189,8 @@ func (a *AerospikeBackend) Get(_ context.Context, key string) (*physical.Entry,
 
 // Delete is used to permanently delete an entry.
 func (a *AerospikeBackend) Delete(_ context.Context, key string) error {
+	defer metrics.MeasureSince([]string{"aerospike", "delete"}, time.Now())
+
 	aeroKey, err := a.key(key)
 	if err != nil {
 		return err
@@ -196,6 +203,8 @@ func (a *AerospikeBackend) Delete(_ context.Context, key string) error {
 // List is used to list all the keys under a given
 // prefix, up to the next prefix.
 func (a *AerospikeBackend) List(_ context.Context, prefix string) ([]string, error) {
+	defer metrics.MeasureSince([]string{"aerospike", "list"}, time.Now())
+
 	recordSet, err := a.client.ScanAll(nil, a.namespace, a.set)
 	if err != nil {
 		return nil, err
@@ -602,6 +602,12 @@ func (b *RaftBackend) RegisterMountTablePath(path string) {
 	}
 }
 
+// EntrySizeLimitForPath returns the largest value, in bytes, that can be
+// stored at the given path.
+func (b *RaftBackend) EntrySizeLimitForPath(path string) uint64 {
+	return b.entrySizeLimitForPath(path)
+}
+
 // GetSpecialPathLimits returns any paths registered with special entry size
 // limits. It's really only used to make integration testing of the plumbing for
 // these paths simpler.
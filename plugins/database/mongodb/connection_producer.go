@@ -10,9 +10,11 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/vault/sdk/database/helper/connutil"
 	"github.com/hashicorp/vault/sdk/database/helper/dbutil"
 	"github.com/mitchellh/mapstructure"
@@ -33,11 +35,14 @@ type mongoDBConnectionProducer struct {
 
 	TLSCertificateKeyData []byte `json:"tls_certificate_key" structs:"-" mapstructure:"tls_certificate_key"`
 	TLSCAData             []byte `json:"tls_ca"              structs:"-" mapstructure:"tls_ca"`
+	InsecureTLS           bool   `json:"insecure_tls"        structs:"-" mapstructure:"insecure_tls"`
 
 	SocketTimeout          time.Duration `json:"socket_timeout"           structs:"-" mapstructure:"socket_timeout"`
 	ConnectTimeout         time.Duration `json:"connect_timeout"          structs:"-" mapstructure:"connect_timeout"`
 	ServerSelectionTimeout time.Duration `json:"server_selection_timeout" structs:"-" mapstructure:"server_selection_timeout"`
 
+	MaxOpenConnections int `json:"max_open_connections" structs:"-" mapstructure:"max_open_connections"`
+
 	Initialized   bool
 	RawConfig     map[string]interface{}
 	Type          string
@@ -56,10 +61,17 @@ type writeConcern struct {
 }
 
 func (c *mongoDBConnectionProducer) loadConfig(cfg map[string]interface{}) error {
-	err := mapstructure.WeakDecode(cfg, c)
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       durationSecondHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           c,
+	})
 	if err != nil {
 		return err
 	}
+	if err := decoder.Decode(cfg); err != nil {
+		return err
+	}
 
 	if len(c.ConnectionURL) == 0 {
 		return fmt.Errorf("connection_url cannot be empty")
@@ -74,6 +86,9 @@ func (c *mongoDBConnectionProducer) loadConfig(cfg map[string]interface{}) error
 	if c.ServerSelectionTimeout < 0 {
 		return fmt.Errorf("server_selection_timeout must be >= 0")
 	}
+	if c.MaxOpenConnections < 0 {
+		return fmt.Errorf("max_open_connections must be >= 0")
+	}
 
 	opts, err := c.makeClientOpts()
 	if err != nil {
@@ -85,6 +100,18 @@ func (c *mongoDBConnectionProducer) loadConfig(cfg map[string]interface{}) error
 	return nil
 }
 
+// durationSecondHookFunc decodes durations with parseutil.ParseDurationSecond,
+// so they may be given as duration strings, or as numbers of seconds in a
+// string, a JSON number or a number.
+func durationSecondHookFunc() mapstructure.DecodeHookFuncType {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if t != reflect.TypeOf(time.Duration(0)) {
+			return data, nil
+		}
+		return parseutil.ParseDurationSecond(data)
+	}
+}
+
 // Connection creates or returns an existing a database connection. If the session fails
 // on a ping check, the session will be closed and then re-created.
 // This method does locks the mutex on its own.
@@ -174,7 +201,7 @@ func (c *mongoDBConnectionProducer) makeClientOpts() (*options.ClientOptions, er
 		return nil, err
 	}
 
-	opts := options.MergeClientOptions(writeOpts, authOpts, timeoutOpts)
+	opts := options.MergeClientOptions(writeOpts, authOpts, timeoutOpts, c.poolOpts())
 	return opts, nil
 }
 
@@ -230,13 +257,15 @@ func (c *mongoDBConnectionProducer) getWriteConcern() (opts *options.ClientOptio
 }
 
 func (c *mongoDBConnectionProducer) getTLSAuth() (opts *options.ClientOptions, err error) {
-	if len(c.TLSCAData) == 0 && len(c.TLSCertificateKeyData) == 0 {
+	if len(c.TLSCAData) == 0 && len(c.TLSCertificateKeyData) == 0 && !c.InsecureTLS {
 		return nil, nil
 	}
 
 	opts = options.Client()
 
-	tlsConfig := &tls.Config{}
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureTLS,
+	}
 
 	if len(c.TLSCAData) > 0 {
 		tlsConfig.RootCAs = x509.NewCertPool()
@@ -290,3 +319,12 @@ func (c *mongoDBConnectionProducer) timeoutOpts() (opts *options.ClientOptions,
 
 	return opts, nil
 }
+
+// poolOpts limits the number of connections the client keeps open to each
+// server. If max_open_connections isn't set, the driver default is used.
+func (c *mongoDBConnectionProducer) poolOpts() *options.ClientOptions {
+	if c.MaxOpenConnections == 0 {
+		return nil
+	}
+	return options.Client().SetMaxPoolSize(uint64(c.MaxOpenConnections))
+}
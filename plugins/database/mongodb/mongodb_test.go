@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -362,6 +363,7 @@ func TestGetTLSAuth(t *testing.T) {
 		username   string
 		tlsCAData  []byte
 		tlsKeyData []byte
+		insecure   bool
 
 		expectOpts *options.ClientOptions
 		expectErr  bool
@@ -411,6 +413,30 @@ func TestGetTLSAuth(t *testing.T) {
 				}),
 			expectErr: false,
 		},
+		"insecure": {
+			insecure: true,
+
+			expectOpts: options.Client().
+				SetTLSConfig(
+					&tls.Config{
+						InsecureSkipVerify: true,
+					},
+				),
+			expectErr: false,
+		},
+		"insecure with ca": {
+			tlsCAData: cert.Pem,
+			insecure:  true,
+
+			expectOpts: options.Client().
+				SetTLSConfig(
+					&tls.Config{
+						RootCAs:            appendToCertPool(t, x509.NewCertPool(), cert.Pem),
+						InsecureSkipVerify: true,
+					},
+				),
+			expectErr: false,
+		},
 	}
 
 	for name, test := range tests {
@@ -419,6 +445,7 @@ func TestGetTLSAuth(t *testing.T) {
 			c.Username = test.username
 			c.TLSCAData = test.tlsCAData
 			c.TLSCertificateKeyData = test.tlsKeyData
+			c.InsecureTLS = test.insecure
 
 			actual, err := c.getTLSAuth()
 			if test.expectErr && err == nil {
@@ -432,6 +459,101 @@ func TestGetTLSAuth(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_connectionOptions(t *testing.T) {
+	type testCase struct {
+		config map[string]interface{}
+
+		expectSocketTimeout time.Duration
+		expectMaxPoolSize   *uint64
+		expectErr           bool
+	}
+
+	maxPoolSize := uint64(10)
+
+	tests := map[string]testCase{
+		"defaults": {
+			config: map[string]interface{}{},
+
+			expectSocketTimeout: 1 * time.Minute,
+		},
+		"duration strings": {
+			config: map[string]interface{}{
+				"socket_timeout": "5s",
+			},
+
+			expectSocketTimeout: 5 * time.Second,
+		},
+		"seconds string": {
+			config: map[string]interface{}{
+				"socket_timeout": "30",
+			},
+
+			expectSocketTimeout: 30 * time.Second,
+		},
+		"seconds number": {
+			config: map[string]interface{}{
+				"socket_timeout": 30,
+			},
+
+			expectSocketTimeout: 30 * time.Second,
+		},
+		"seconds json.Number": {
+			config: map[string]interface{}{
+				"socket_timeout": json.Number("30"),
+			},
+
+			expectSocketTimeout: 30 * time.Second,
+		},
+		"invalid duration": {
+			config: map[string]interface{}{
+				"socket_timeout": "soon",
+			},
+
+			expectErr: true,
+		},
+		"max open connections": {
+			config: map[string]interface{}{
+				"max_open_connections": 10,
+			},
+
+			expectSocketTimeout: 1 * time.Minute,
+			expectMaxPoolSize:   &maxPoolSize,
+		},
+		"negative max open connections": {
+			config: map[string]interface{}{
+				"max_open_connections": -1,
+			},
+
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.config["connection_url"] = "mongodb://localhost:27017"
+
+			c := new()
+			err := c.loadConfig(test.config)
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("err expected, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("no error expected, got: %s", err)
+			}
+
+			if *c.clientOptions.SocketTimeout != test.expectSocketTimeout {
+				t.Fatalf("expected socket timeout %s, got %s", test.expectSocketTimeout, *c.clientOptions.SocketTimeout)
+			}
+			if !reflect.DeepEqual(c.clientOptions.MaxPoolSize, test.expectMaxPoolSize) {
+				t.Fatalf("expected max pool size %v, got %v", test.expectMaxPoolSize, c.clientOptions.MaxPoolSize)
+			}
+		})
+	}
+}
+
 func appendToCertPool(t *testing.T, pool *x509.CertPool, caPem []byte) *x509.CertPool {
 	t.Helper()
 
@@ -70,6 +70,11 @@ type PluginRunner struct {
 	BuiltinFactory func() (interface{}, error) `json:"-" structs:"-"`
 	RuntimeConfig  *prutil.PluginRuntimeConfig `json:"-" structs:"-"`
 	Tmpdir         string                      `json:"-" structs:"-"`
+
+	// DownloadURL and DownloadSignature are set for plugins registered with a
+	// download URL, so that each node can fetch the binary if it's missing.
+	DownloadURL       string `json:"download_url,omitempty" structs:"-"`
+	DownloadSignature []byte `json:"download_signature,omitempty" structs:"-"`
 }
 
 // BinaryReference returns either the OCI image reference if it's a container
@@ -103,6 +108,11 @@ type SetPluginInput struct {
 	Args     []string
 	Env      []string
 	Sha256   []byte
+
+	// DownloadURL, if set, is fetched into the plugin directory as Command
+	// once its SHA256 sum and DownloadSignature have been verified.
+	DownloadURL       string
+	DownloadSignature []byte
 }
 
 // Run takes a wrapper RunnerUtil instance along with the go-plugin parameters and
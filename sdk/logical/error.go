@@ -71,6 +71,17 @@ var (
 	ErrNotFound = errors.New("not found")
 )
 
+// Error codes reported to clients in the error_code field of HTTP error
+// responses, so that common failures can be told apart without matching on
+// error messages.
+const (
+	ErrorCodePermissionDenied = "permission_denied"
+	ErrorCodeSealed           = "sealed"
+	ErrorCodeStandby          = "standby"
+	ErrorCodeQuotaExceeded    = "quota_exceeded"
+	ErrorCodeMFAFailed        = "mfa_failed"
+)
+
 type DelegatedAuthErrorHandler func(ctx context.Context, initiatingRequest, authRequest *Request, authResponse *Response, err error) (*Response, error)
 
 var _ error = &RequestDelegatedAuthError{}
@@ -139,6 +150,29 @@ func (e *codedError) Code() int {
 	return e.Status
 }
 
+// WithErrorCode returns an error that behaves like err, but is reported to
+// clients with the given error code. It takes precedence over the code that
+// would otherwise be derived from err.
+func WithErrorCode(err error, code string) error {
+	return &errorCodeError{
+		err:  err,
+		code: code,
+	}
+}
+
+type errorCodeError struct {
+	err  error
+	code string
+}
+
+func (e *errorCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *errorCodeError) Unwrap() error {
+	return e.err
+}
+
 // Struct to identify user input errors.  This is helpful in responding the
 // appropriate status codes to clients from the HTTP endpoints.
 type StatusBadRequest struct {
@@ -147,7 +147,13 @@ func RespondErrorCommon(req *Request, resp *Response, err error) (int, error) {
 	}
 
 	if respErr := resp.Error(); respErr != nil {
+		// Keep reporting the error code of the original error, since the
+		// error in the response replaces it
+		code := ErrorCode(err)
 		err = fmt.Errorf("%s", respErr.Error())
+		if code != "" {
+			err = WithErrorCode(err, code)
+		}
 
 		// Don't let other error codes override the overloaded status code
 		if strings.Contains(respErr.Error(), consts.ErrOverloaded.Error()) {
@@ -194,6 +200,33 @@ func AdjustErrorStatusCode(status *int, err error) {
 	}
 }
 
+// ErrorCode returns the error code reported to clients for err, or an empty
+// string if err doesn't match any of the known failures.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var codeErr *errorCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.code
+	}
+
+	switch {
+	case errwrap.Contains(err, consts.ErrSealed.Error()):
+		return ErrorCodeSealed
+	case errwrap.Contains(err, consts.ErrStandby.Error()):
+		return ErrorCodeStandby
+	case errwrap.Contains(err, ErrRateLimitQuotaExceeded.Error()),
+		errwrap.Contains(err, ErrLeaseCountQuotaExceeded.Error()):
+		return ErrorCodeQuotaExceeded
+	case errwrap.Contains(err, ErrPermissionDenied.Error()):
+		return ErrorCodePermissionDenied
+	}
+
+	return ""
+}
+
 func RespondError(w http.ResponseWriter, status int, err error) {
 	AdjustErrorStatusCode(&status, err)
 
@@ -201,11 +234,13 @@ func RespondError(w http.ResponseWriter, status int, err error) {
 	w.WriteHeader(status)
 
 	type ErrorResponse struct {
-		Errors []string `json:"errors"`
+		Errors    []string `json:"errors"`
+		ErrorCode string   `json:"error_code,omitempty"`
 	}
 	resp := &ErrorResponse{Errors: make([]string, 0, 1)}
 	if err != nil {
 		resp.Errors = append(resp.Errors, err.Error())
+		resp.ErrorCode = ErrorCode(err)
 	}
 
 	enc := json.NewEncoder(w)
@@ -219,12 +254,14 @@ func RespondErrorAndData(w http.ResponseWriter, status int, data interface{}, er
 	w.WriteHeader(status)
 
 	type ErrorAndDataResponse struct {
-		Errors []string    `json:"errors"`
-		Data   interface{} `json:"data"`
+		Errors    []string    `json:"errors"`
+		ErrorCode string      `json:"error_code,omitempty"`
+		Data      interface{} `json:"data"`
 	}
 	resp := &ErrorAndDataResponse{Errors: make([]string, 0, 1)}
 	if err != nil {
 		resp.Errors = append(resp.Errors, err.Error())
+		resp.ErrorCode = ErrorCode(err)
 	}
 	resp.Data = data
 
@@ -5,9 +5,11 @@ package logical
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/vault/sdk/helper/consts"
 )
 
@@ -117,3 +119,68 @@ func TestResponseUtil_RespondErrorCommon_basic(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseUtil_ErrorCode(t *testing.T) {
+	testCases := []struct {
+		title    string
+		err      error
+		expected string
+	}{
+		{
+			title: "No error",
+		},
+		{
+			title: "Unknown error",
+			err:   errors.New("unknown"),
+		},
+		{
+			title:    "Permission denied",
+			err:      multierror.Append(nil, ErrPermissionDenied),
+			expected: ErrorCodePermissionDenied,
+		},
+		{
+			title:    "Sealed",
+			err:      consts.ErrSealed,
+			expected: ErrorCodeSealed,
+		},
+		{
+			title:    "Standby",
+			err:      fmt.Errorf("request failed: %w", consts.ErrStandby),
+			expected: ErrorCodeStandby,
+		},
+		{
+			title:    "Rate limit quota exceeded",
+			err:      fmt.Errorf("request path %q: %w", "foo", errors.New(ErrRateLimitQuotaExceeded.Error())),
+			expected: ErrorCodeQuotaExceeded,
+		},
+		{
+			title:    "Lease count quota exceeded",
+			err:      ErrLeaseCountQuotaExceeded,
+			expected: ErrorCodeQuotaExceeded,
+		},
+		{
+			title:    "Explicit code",
+			err:      multierror.Append(nil, WithErrorCode(ErrPermissionDenied, ErrorCodeMFAFailed)),
+			expected: ErrorCodeMFAFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			if code := ErrorCode(tc.err); code != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, code)
+			}
+		})
+	}
+}
+
+func TestResponseUtil_RespondErrorCommon_errorCode(t *testing.T) {
+	resp := ErrorResponse("failed to satisfy enforcement")
+	_, err := RespondErrorCommon(&Request{}, resp, WithErrorCode(ErrPermissionDenied, ErrorCodeMFAFailed))
+	if err.Error() != "failed to satisfy enforcement" {
+		t.Fatalf("bad error: %v", err)
+	}
+	if code := ErrorCode(err); code != ErrorCodeMFAFailed {
+		t.Fatalf("expected %q, got %q", ErrorCodeMFAFailed, code)
+	}
+}
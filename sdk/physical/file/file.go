@@ -14,7 +14,9 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/errwrap"
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/vault/sdk/helper/consts"
@@ -82,6 +84,8 @@ func NewTransactionalFileBackend(conf map[string]string, logger log.Logger) (phy
 }
 
 func (b *FileBackend) Delete(ctx context.Context, path string) error {
+	defer metrics.MeasureSince([]string{"file", "delete"}, time.Now())
+
 	b.permitPool.Acquire()
 	defer b.permitPool.Release()
 
@@ -157,6 +161,8 @@ func (b *FileBackend) cleanupLogicalPath(path string) error {
 }
 
 func (b *FileBackend) Get(ctx context.Context, k string) (*physical.Entry, error) {
+	defer metrics.MeasureSince([]string{"file", "get"}, time.Now())
+
 	b.permitPool.Acquire()
 	defer b.permitPool.Release()
 
@@ -216,6 +222,8 @@ func (b *FileBackend) GetInternal(ctx context.Context, k string) (*physical.Entr
 }
 
 func (b *FileBackend) Put(ctx context.Context, entry *physical.Entry) error {
+	defer metrics.MeasureSince([]string{"file", "put"}, time.Now())
+
 	b.permitPool.Acquire()
 	defer b.permitPool.Release()
 
@@ -295,6 +303,8 @@ func (b *FileBackend) PutInternal(ctx context.Context, entry *physical.Entry) er
 }
 
 func (b *FileBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	defer metrics.MeasureSince([]string{"file", "list"}, time.Now())
+
 	b.permitPool.Acquire()
 	defer b.permitPool.Release()
 
@@ -307,8 +307,13 @@ func (a *ACL) CapabilitiesAndSubscribeEventTypes(ctx context.Context, path strin
 		return []string{RootCapability}, []string{"*"}
 	}
 	subscribeEventTypes = res.SubscribeEventTypes
-	capabilities := res.CapabilitiesBitmap
+	pathCapabilities = capabilitiesFromBitmap(res.CapabilitiesBitmap)
+	return
+}
 
+// capabilitiesFromBitmap returns the names of the capabilities set in
+// capabilities, or just deny if deny is set or no capability is.
+func capabilitiesFromBitmap(capabilities uint32) (pathCapabilities []string) {
 	if capabilities&SudoCapabilityInt > 0 {
 		pathCapabilities = append(pathCapabilities, SudoCapability)
 	}
@@ -394,42 +399,14 @@ func (a *ACL) AllowOperation(ctx context.Context, req *logical.Request, capCheck
 
 	// Find an exact matching rule, look for prefix if no match
 	var capabilities uint32
-	raw, ok := a.exactRules.Get(path)
-	if ok {
-		permissions = raw.(*ACLPermissions)
-		capabilities = permissions.CapabilitiesBitmap
-		goto CHECK
-	}
-	if op == logical.ListOperation {
-		raw, ok = a.exactRules.Get(strings.TrimSuffix(path, "/"))
-		if ok {
-			permissions = raw.(*ACLPermissions)
-			capabilities = permissions.CapabilitiesBitmap
-			goto CHECK
-		}
-	}
-
-	// List operations need to check without the trailing slash first, because
-	// there could be other rules with trailing wildcards that will match the
-	// path
-	if op == logical.ListOperation && strings.HasSuffix(path, "/") {
-		permissions = a.CheckAllowedFromNonExactPaths(strings.TrimSuffix(path, "/"), false)
-		if permissions != nil {
-			capabilities = permissions.CapabilitiesBitmap
-			goto CHECK
-		}
-	}
-	permissions = a.CheckAllowedFromNonExactPaths(path, false)
-	if permissions != nil {
-		capabilities = permissions.CapabilitiesBitmap
-		goto CHECK
+	permissions, _ = a.matchingRule(path, op)
+	if permissions == nil {
+		// No exact, prefix, or segment wildcard paths found, return without
+		// setting allowed
+		return
 	}
+	capabilities = permissions.CapabilitiesBitmap
 
-	// No exact, prefix, or segment wildcard paths found, return without
-	// setting allowed
-	return
-
-CHECK:
 	// Check if the minimum permissions are met
 	// If "deny" has been explicitly set, only deny will be in the map, so we
 	// only need to check for the existence of other values
@@ -482,7 +459,9 @@ CHECK:
 		return
 	}
 
-	ret.GrantingPolicies = grantingPolicies
+	// ACLs may be shared between requests, so make sure callers appending
+	// to the result don't write into the ACL's own slice
+	ret.GrantingPolicies = slices.Clip(grantingPolicies)
 
 	if permissions.MaxWrappingTTL > 0 {
 		if req.WrapInfo == nil || req.WrapInfo.TTL > permissions.MaxWrappingTTL {
@@ -568,11 +547,39 @@ CHECK:
 	return
 }
 
+// matchingRule returns the permissions of the rule that applies to the given
+// namespace-qualified path for op, along with the path pattern of that rule
+// as written in the policy. It returns nil if no rule matches.
+func (a *ACL) matchingRule(path string, op logical.Operation) (*ACLPermissions, string) {
+	raw, ok := a.exactRules.Get(path)
+	if ok {
+		return raw.(*ACLPermissions), path
+	}
+	if op == logical.ListOperation {
+		trimmed := strings.TrimSuffix(path, "/")
+		raw, ok = a.exactRules.Get(trimmed)
+		if ok {
+			return raw.(*ACLPermissions), trimmed
+		}
+	}
+
+	// List operations need to check without the trailing slash first, because
+	// there could be other rules with trailing wildcards that will match the
+	// path
+	if op == logical.ListOperation && strings.HasSuffix(path, "/") {
+		if permissions, rule := a.checkAllowedFromNonExactPaths(strings.TrimSuffix(path, "/"), false); permissions != nil {
+			return permissions, rule
+		}
+	}
+	return a.checkAllowedFromNonExactPaths(path, false)
+}
+
 type wcPathDescr struct {
 	firstWCOrGlob int
 	wildcards     int
 	isPrefix      bool
 	wcPath        string
+	rule          string
 	perms         *ACLPermissions
 }
 
@@ -582,6 +589,13 @@ type wcPathDescr struct {
 // of permissions from some allowed path underneath the mount (for use in mount
 // access checks), or nil indicating no non-deny permissions were found.
 func (a *ACL) CheckAllowedFromNonExactPaths(path string, bareMount bool) *ACLPermissions {
+	permissions, _ := a.checkAllowedFromNonExactPaths(path, bareMount)
+	return permissions
+}
+
+// checkAllowedFromNonExactPaths is CheckAllowedFromNonExactPaths but also
+// returns the path pattern of the matching rule.
+func (a *ACL) checkAllowedFromNonExactPaths(path string, bareMount bool) (*ACLPermissions, string) {
 	wcPathDescrs := make([]wcPathDescr, 0, len(a.segmentWildcardPaths)+1)
 
 	less := func(i, j int) bool {
@@ -645,11 +659,12 @@ func (a *ACL) CheckAllowedFromNonExactPaths(path string, bareMount bool) *ACLPer
 		prefix, raw, ok := a.prefixRules.LongestPrefix(path)
 		if ok {
 			if len(a.segmentWildcardPaths) == 0 {
-				return raw.(*ACLPermissions)
+				return raw.(*ACLPermissions), prefix + "*"
 			}
 			wcPathDescrs = append(wcPathDescrs, wcPathDescr{
 				firstWCOrGlob: len(prefix),
 				wcPath:        prefix,
+				rule:          prefix + "*",
 				isPrefix:      true,
 				perms:         raw.(*ACLPermissions),
 			})
@@ -657,7 +672,7 @@ func (a *ACL) CheckAllowedFromNonExactPaths(path string, bareMount bool) *ACLPer
 	}
 
 	if len(a.segmentWildcardPaths) == 0 {
-		return nil
+		return nil, ""
 	}
 
 	pathParts := strings.Split(path, "/")
@@ -667,7 +682,7 @@ SWCPATH:
 		if fullWCPath == "" {
 			continue
 		}
-		pd := wcPathDescr{firstWCOrGlob: strings.Index(fullWCPath, "+")}
+		pd := wcPathDescr{firstWCOrGlob: strings.Index(fullWCPath, "+"), rule: fullWCPath}
 
 		currWCPath := fullWCPath
 		if currWCPath[len(currWCPath)-1] == '*' {
@@ -713,7 +728,7 @@ SWCPATH:
 				if strings.HasPrefix(joinedPath, path) {
 					permissions := a.segmentWildcardPaths[fullWCPath].(*ACLPermissions)
 					if permissions.CapabilitiesBitmap&DenyCapabilityInt == 0 && permissions.CapabilitiesBitmap > 0 {
-						return permissions
+						return permissions, fullWCPath
 					}
 				}
 				continue SWCPATH
@@ -724,14 +739,15 @@ SWCPATH:
 	}
 
 	if bareMount || len(wcPathDescrs) == 0 {
-		return nil
+		return nil, ""
 	}
 
 	// We don't do this in the bare mount check because we don't care about
 	// priority, we only care about any capability at all.
 	sort.Slice(wcPathDescrs, less)
 
-	return wcPathDescrs[len(wcPathDescrs)-1].perms
+	match := wcPathDescrs[len(wcPathDescrs)-1]
+	return match.perms, match.rule
 }
 
 func (c *Core) performPolicyChecks(ctx context.Context, acl *ACL, te *logical.TokenEntry, req *logical.Request, inEntity *identity.Entity, opts *PolicyCheckOpts) *AuthResults {
@@ -248,6 +248,7 @@ func (c *Core) enableCredentialInternal(ctx context.Context, entry *MountEntry,
 	if c.logger.IsInfo() {
 		c.logger.Info("enabled credential backend", "path", entry.Path, "type", entry.Type, "version", entry.RunningVersion)
 	}
+	c.sendMountEvent(entry, eventTypeMountEnable)
 	return nil
 }
 
@@ -379,6 +380,7 @@ func (c *Core) disableCredentialInternal(ctx context.Context, path string, updat
 	if c.logger.IsInfo() {
 		c.logger.Info("disabled credential backend", "path", path)
 	}
+	c.sendMountEvent(entry, eventTypeMountDisable)
 
 	return nil
 }
@@ -575,12 +577,12 @@ func (c *Core) taintCredEntry(ctx context.Context, nsID, path string, updateStor
 // loadCredentials is invoked as part of postUnseal to load the auth table
 func (c *Core) loadCredentials(ctx context.Context) error {
 	// Load the existing mount table
-	raw, err := c.barrier.Get(ctx, coreAuthConfigPath)
+	raw, err := c.getMountTableEntry(ctx, coreAuthConfigPath)
 	if err != nil {
 		c.logger.Error("failed to read auth table", "error", err)
 		return errLoadAuthFailed
 	}
-	rawLocal, err := c.barrier.Get(ctx, coreLocalAuthConfigPath)
+	rawLocal, err := c.getMountTableEntry(ctx, coreLocalAuthConfigPath)
 	if err != nil {
 		c.logger.Error("failed to read local auth table", "error", err)
 		return errLoadAuthFailed
@@ -731,7 +733,7 @@ func (c *Core) persistAuth(ctx context.Context, table *MountTable, local *bool)
 		}
 
 		// Write to the physical backend
-		if err := c.barrier.Put(ctx, entry); err != nil {
+		if err := c.putMountTableEntry(ctx, entry); err != nil {
 			c.logger.Error("failed to persist auth mount table", "error", err)
 			return nil, err
 		}
@@ -948,6 +950,10 @@ func (c *Core) teardownCredentials(ctx context.Context) error {
 
 	c.auth = nil
 	c.tokenStore = nil
+
+	// Another active node may shard the tables before this one is active again
+	c.mountTablesUnsharded.Delete(coreAuthConfigPath)
+	c.mountTablesUnsharded.Delete(coreLocalAuthConfigPath)
 	return nil
 }
 
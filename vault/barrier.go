@@ -186,6 +186,15 @@ type BarrierStorage interface {
 	List(ctx context.Context, prefix string) ([]string, error)
 }
 
+// transactionalBarrier is implemented by barriers that can write a batch of
+// entries atomically.
+type transactionalBarrier interface {
+	// putEntries writes all entries in a single transaction when the
+	// physical backend supports transactions, and one at a time, in the
+	// given order, otherwise.
+	putEntries(ctx context.Context, entries []*logical.StorageEntry) error
+}
+
 // BarrierEncryptor is the in memory only interface that does not actually
 // use the underlying barrier. It is used for lower level modules like the
 // Write-Ahead-Log and Merkle index to allow them to use the barrier.
@@ -8,6 +8,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/binary"
 	"errors"
@@ -24,10 +25,12 @@ import (
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/hashicorp/vault/helper/locking"
+	"github.com/hashicorp/vault/helper/tracing"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/hashicorp/vault/sdk/physical"
 	"go.uber.org/atomic"
+	"golang.org/x/crypto/hkdf"
 )
 
 const (
@@ -49,8 +52,14 @@ const (
 const (
 	AESGCMVersion1 = 0x1
 	AESGCMVersion2 = 0x2
+	// AESGCMVersion3 is like AESGCMVersion2, but the value is encrypted with
+	// a key derived from the term key and the storage prefix of its path.
+	AESGCMVersion3 = 0x3
 )
 
+// prefixKeyInfo is the HKDF info prefix used when deriving per prefix keys.
+const prefixKeyInfo = "vault-barrier-prefix-key:"
+
 // barrierInit is the JSON encoded value stored
 type barrierInit struct {
 	Version int    // Version is the current format version
@@ -81,8 +90,14 @@ type AESGCMBarrier struct {
 	keyring *Keyring
 
 	// cache is used to reduce the number of AEAD constructions we do
-	cache     map[uint32]cipher.AEAD
-	cacheLock sync.RWMutex
+	cache       map[uint32]cipher.AEAD
+	prefixCache map[prefixCacheKey]cipher.AEAD
+	cacheLock   sync.RWMutex
+
+	// prefixKeyDerivation causes new values to be written with
+	// AESGCMVersion3 using per prefix keys. Values are always decrypted
+	// according to their own version byte, so it can be toggled at any time.
+	prefixKeyDerivation bool
 
 	// currentAESGCMVersionByte is prefixed to a message to allow for
 	// future versioning of barrier implementations. It's var instead
@@ -136,6 +151,7 @@ func NewAESGCMBarrier(physical physical.Backend, detectDeadlocks bool) (*AESGCMB
 		l:                        &locking.SyncRWMutex{},
 		sealed:                   true,
 		cache:                    make(map[uint32]cipher.AEAD),
+		prefixCache:              make(map[prefixCacheKey]cipher.AEAD),
 		currentAESGCMVersionByte: byte(AESGCMVersion2),
 		UnaccountedEncryptions:   atomic.NewInt64(0),
 		RemoteEncryptions:        atomic.NewInt64(0),
@@ -143,7 +159,7 @@ func NewAESGCMBarrier(physical physical.Backend, detectDeadlocks bool) (*AESGCMB
 		bestEffortKeyringTimeout: keyringTimeout,
 	}
 	if detectDeadlocks {
-		b.l = &locking.DeadlockRWMutex{}
+		b.l = &locking.DeadlockRWMutex{Name: "barrier"}
 	}
 	return b, nil
 }
@@ -423,6 +439,7 @@ func (b *AESGCMBarrier) recoverKeyring(plaintext []byte) error {
 
 	// Setup the keyring and finish
 	b.cache = make(map[uint32]cipher.AEAD)
+	b.prefixCache = make(map[prefixCacheKey]cipher.AEAD)
 	b.keyring = keyring
 	return nil
 }
@@ -596,6 +613,7 @@ func (b *AESGCMBarrier) Seal() error {
 
 	// Remove the primary key, and seal the vault
 	b.cache = make(map[uint32]cipher.AEAD)
+	b.prefixCache = make(map[prefixCacheKey]cipher.AEAD)
 	b.keyring.Zeroize(true)
 	b.keyring = nil
 	b.sealed = true
@@ -838,6 +856,8 @@ func (b *AESGCMBarrier) updateRootKeyCommon(key []byte) (*Keyring, error) {
 // Put is used to insert or update an entry
 func (b *AESGCMBarrier) Put(ctx context.Context, entry *logical.StorageEntry) error {
 	defer metrics.MeasureSince([]string{"barrier", "put"}, time.Now())
+	ctx, span := tracing.StartSpan(ctx, "barrier.put")
+	defer span.End()
 	b.l.RLock()
 	if b.sealed {
 		b.l.RUnlock()
@@ -845,7 +865,7 @@ func (b *AESGCMBarrier) Put(ctx context.Context, entry *logical.StorageEntry) er
 	}
 
 	term := b.keyring.ActiveTerm()
-	primary, err := b.aeadForTerm(term)
+	primary, err := b.aeadForWrite(term, entry.Key)
 	b.l.RUnlock()
 	if err != nil {
 		return err
@@ -867,6 +887,57 @@ func (b *AESGCMBarrier) putInternal(ctx context.Context, term uint32, primary ci
 	return b.backend.Put(ctx, pe)
 }
 
+// putEntries implements transactionalBarrier.
+func (b *AESGCMBarrier) putEntries(ctx context.Context, entries []*logical.StorageEntry) error {
+	txnBackend, ok := b.backend.(physical.Transactional)
+	if !ok {
+		for _, entry := range entries {
+			if err := b.Put(ctx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	defer metrics.MeasureSince([]string{"barrier", "put"}, time.Now())
+	ctx, span := tracing.StartSpan(ctx, "barrier.put")
+	defer span.End()
+	b.l.RLock()
+	if b.sealed {
+		b.l.RUnlock()
+		return ErrBarrierSealed
+	}
+
+	term := b.keyring.ActiveTerm()
+	aeads := make([]cipher.AEAD, 0, len(entries))
+	for _, entry := range entries {
+		primary, err := b.aeadForWrite(term, entry.Key)
+		if err != nil {
+			b.l.RUnlock()
+			return err
+		}
+		aeads = append(aeads, primary)
+	}
+	b.l.RUnlock()
+
+	txns := make([]*physical.TxnEntry, 0, len(entries))
+	for i, entry := range entries {
+		value, err := b.encryptTracked(entry.Key, term, aeads[i], entry.Value)
+		if err != nil {
+			return err
+		}
+		txns = append(txns, &physical.TxnEntry{
+			Operation: physical.PutOperation,
+			Entry: &physical.Entry{
+				Key:      entry.Key,
+				Value:    value,
+				SealWrap: entry.SealWrap,
+			},
+		})
+	}
+	return txnBackend.Transaction(ctx, txns)
+}
+
 // Get is used to fetch an entry
 func (b *AESGCMBarrier) Get(ctx context.Context, key string) (*logical.StorageEntry, error) {
 	return b.lockSwitchedGet(ctx, key, true)
@@ -874,6 +945,8 @@ func (b *AESGCMBarrier) Get(ctx context.Context, key string) (*logical.StorageEn
 
 func (b *AESGCMBarrier) lockSwitchedGet(ctx context.Context, key string, getLock bool) (*logical.StorageEntry, error) {
 	defer metrics.MeasureSince([]string{"barrier", "get"}, time.Now())
+	ctx, span := tracing.StartSpan(ctx, "barrier.get")
+	defer span.End()
 	if getLock {
 		b.l.RLock()
 	}
@@ -911,7 +984,7 @@ func (b *AESGCMBarrier) lockSwitchedGet(ctx context.Context, key string, getLock
 	// Get the GCM by term
 	// It is expensive to do this first but it is not a
 	// normal case that this won't match
-	gcm, err := b.aeadForTerm(term)
+	gcm, err := b.aeadForRead(term, key, pe.Value)
 	if getLock {
 		b.l.RUnlock()
 	}
@@ -940,6 +1013,8 @@ func (b *AESGCMBarrier) lockSwitchedGet(ctx context.Context, key string, getLock
 // Delete is used to permanently delete an entry
 func (b *AESGCMBarrier) Delete(ctx context.Context, key string) error {
 	defer metrics.MeasureSince([]string{"barrier", "delete"}, time.Now())
+	ctx, span := tracing.StartSpan(ctx, "barrier.delete")
+	defer span.End()
 	b.l.RLock()
 	sealed := b.sealed
 	b.l.RUnlock()
@@ -954,6 +1029,8 @@ func (b *AESGCMBarrier) Delete(ctx context.Context, key string) error {
 // prefix, up to the next prefix.
 func (b *AESGCMBarrier) List(ctx context.Context, prefix string) ([]string, error) {
 	defer metrics.MeasureSince([]string{"barrier", "list"}, time.Now())
+	ctx, span := tracing.StartSpan(ctx, "barrier.list")
+	defer span.End()
 	b.l.RLock()
 	sealed := b.sealed
 	b.l.RUnlock()
@@ -999,6 +1076,93 @@ func (b *AESGCMBarrier) aeadForTerm(term uint32) (cipher.AEAD, error) {
 	return aead, nil
 }
 
+// prefixCacheKey identifies a derived AEAD in the prefix cache.
+type prefixCacheKey struct {
+	term   uint32
+	prefix string
+}
+
+// prefixAEAD is an AEAD using a key derived for a storage prefix. Values
+// encrypted with it are marked with AESGCMVersion3.
+type prefixAEAD struct {
+	cipher.AEAD
+}
+
+// barrierKeyPrefix returns the storage prefix whose derived key protects
+// path. Each mount gets its own key, so paths under logical/ and auth/ are
+// separated by mount UUID, while other paths are separated by their top level
+// directory.
+func barrierKeyPrefix(path string) string {
+	first, rest, found := strings.Cut(path, "/")
+	if !found {
+		return ""
+	}
+	first += "/"
+	if first == backendBarrierPrefix || first == credentialBarrierPrefix {
+		if mountUUID, _, found := strings.Cut(rest, "/"); found {
+			return first + mountUUID + "/"
+		}
+	}
+	return first
+}
+
+// aeadForWrite returns the AEAD used to encrypt a new value for path with
+// the given term.
+func (b *AESGCMBarrier) aeadForWrite(term uint32, path string) (cipher.AEAD, error) {
+	if b.prefixKeyDerivation {
+		return b.aeadForPrefix(term, barrierKeyPrefix(path))
+	}
+	return b.aeadForTerm(term)
+}
+
+// aeadForRead returns the AEAD needed to decrypt value, which was stored at
+// path with the given term.
+func (b *AESGCMBarrier) aeadForRead(term uint32, path string, value []byte) (cipher.AEAD, error) {
+	if len(value) > termSize && value[termSize] == AESGCMVersion3 {
+		return b.aeadForPrefix(term, barrierKeyPrefix(path))
+	}
+	return b.aeadForTerm(term)
+}
+
+// aeadForPrefix returns the AES-GCM AEAD for the given term and storage
+// prefix, using a key derived from the term key with HKDF-SHA256.
+func (b *AESGCMBarrier) aeadForPrefix(term uint32, prefix string) (cipher.AEAD, error) {
+	keyring := b.keyring
+	if keyring == nil {
+		return nil, nil
+	}
+
+	cacheKey := prefixCacheKey{term: term, prefix: prefix}
+	b.cacheLock.RLock()
+	aead, ok := b.prefixCache[cacheKey]
+	b.cacheLock.RUnlock()
+	if ok {
+		return aead, nil
+	}
+
+	key := keyring.TermKey(term)
+	if key == nil {
+		return nil, nil
+	}
+
+	derived := make([]byte, len(key.Value))
+	defer memzero(derived)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key.Value, nil, []byte(prefixKeyInfo+prefix)), derived); err != nil {
+		return nil, fmt.Errorf("failed to derive prefix key: %w", err)
+	}
+
+	gcm, err := b.aeadFromKey(derived)
+	if err != nil {
+		return nil, err
+	}
+	aead = &prefixAEAD{AEAD: gcm}
+
+	b.cacheLock.Lock()
+	b.prefixCache[cacheKey] = aead
+	b.cacheLock.Unlock()
+	return aead, nil
+}
+
 // aeadFromKey returns an AES-GCM AEAD using the given key.
 func (b *AESGCMBarrier) aeadFromKey(key []byte) (cipher.AEAD, error) {
 	// Create the AES cipher
@@ -1033,7 +1197,11 @@ func (b *AESGCMBarrier) encrypt(path string, term uint32, gcm cipher.AEAD, plain
 	binary.BigEndian.PutUint32(out[:4], term)
 
 	// Set the version byte
-	out[4] = b.currentAESGCMVersionByte
+	version := b.currentAESGCMVersionByte
+	if _, ok := gcm.(*prefixAEAD); ok {
+		version = AESGCMVersion3
+	}
+	out[4] = version
 
 	// Generate a random nonce
 	nonce := out[5 : 5+gcm.NonceSize()]
@@ -1046,10 +1214,10 @@ func (b *AESGCMBarrier) encrypt(path string, term uint32, gcm cipher.AEAD, plain
 	}
 
 	// Seal the output
-	switch b.currentAESGCMVersionByte {
+	switch version {
 	case AESGCMVersion1:
 		out = gcm.Seal(out, nonce, plain, nil)
-	case AESGCMVersion2:
+	case AESGCMVersion2, AESGCMVersion3:
 		aad := []byte(nil)
 		if path != "" {
 			aad = []byte(path)
@@ -1085,7 +1253,7 @@ func (b *AESGCMBarrier) decrypt(path string, gcm cipher.AEAD, cipher []byte) ([]
 	switch cipher[4] {
 	case AESGCMVersion1:
 		return gcm.Open(out, nonce, raw, nil)
-	case AESGCMVersion2:
+	case AESGCMVersion2, AESGCMVersion3:
 		aad := []byte(nil)
 		if path != "" {
 			aad = []byte(path)
@@ -1105,7 +1273,7 @@ func (b *AESGCMBarrier) Encrypt(ctx context.Context, key string, plaintext []byt
 	}
 
 	term := b.keyring.ActiveTerm()
-	primary, err := b.aeadForTerm(term)
+	primary, err := b.aeadForWrite(term, key)
 	b.l.RUnlock()
 	if err != nil {
 		return nil, err
@@ -1142,7 +1310,7 @@ func (b *AESGCMBarrier) Decrypt(_ context.Context, key string, ciphertext []byte
 	// Get the GCM by term
 	// It is expensive to do this first but it is not a
 	// normal case that this won't match
-	gcm, err := b.aeadForTerm(term)
+	gcm, err := b.aeadForRead(term, key, ciphertext)
 	b.l.RUnlock()
 	if err != nil {
 		return nil, err
@@ -349,6 +349,77 @@ func TestAESGCMBarrier_MoveIntegrityV1(t *testing.T) {
 	}
 }
 
+func TestAESGCMBarrier_PrefixKeyDerivation(t *testing.T) {
+	inm, b, _ := mockBarrier(t)
+	ctx := context.Background()
+
+	// Entries written before derivation is enabled use the term key
+	legacy := &logical.StorageEntry{Key: "logical/mount-a/legacy", Value: []byte("legacy")}
+	if err := b.Put(ctx, legacy); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	b.(*AESGCMBarrier).prefixKeyDerivation = true
+	for _, key := range []string{"logical/mount-a/test", "logical/mount-b/test", "sys/test", "test"} {
+		if err := b.Put(ctx, &logical.StorageEntry{Key: key, Value: []byte(key)}); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		pe, _ := inm.Get(ctx, key)
+		if pe.Value[termSize] != AESGCMVersion3 {
+			t.Fatalf("expected version 3 for %q, got %d", key, pe.Value[termSize])
+		}
+	}
+
+	// Reads follow the version of each entry, regardless of the setting
+	for _, enabled := range []bool{true, false} {
+		b.(*AESGCMBarrier).prefixKeyDerivation = enabled
+		for _, key := range []string{"logical/mount-a/legacy", "logical/mount-a/test", "logical/mount-b/test", "sys/test", "test"} {
+			out, err := b.Get(ctx, key)
+			if err != nil {
+				t.Fatalf("err: %v", err)
+			}
+			if out == nil {
+				t.Fatalf("missing entry %q", key)
+			}
+		}
+	}
+
+	// The term key alone can't decrypt entries written with a derived key
+	term := b.(*AESGCMBarrier).keyring.ActiveTerm()
+	termAEAD, err := b.(*AESGCMBarrier).aeadForTerm(term)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	pe, _ := inm.Get(ctx, "logical/mount-a/test")
+	if _, err := b.(*AESGCMBarrier).decrypt("logical/mount-a/test", termAEAD, pe.Value); err == nil {
+		t.Fatal("expected decryption with the term key to fail")
+	}
+
+	// Entries can't be moved between mounts
+	moved := &physical.Entry{Key: "logical/mount-b/test", Value: pe.Value}
+	if err := inm.Put(ctx, moved); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := b.Get(ctx, "logical/mount-b/test"); err == nil {
+		t.Fatal("expected moved entry to fail decryption")
+	}
+}
+
+func TestBarrierKeyPrefix(t *testing.T) {
+	for path, expected := range map[string]string{
+		"logical/1234/foo/bar": "logical/1234/",
+		"auth/1234/foo":        "auth/1234/",
+		"logical/foo":          "logical/",
+		"sys/policy/default":   "sys/",
+		"core/keyring":         "core/",
+		"foo":                  "",
+	} {
+		if actual := barrierKeyPrefix(path); actual != expected {
+			t.Fatalf("bad prefix for %q: expected %q, got %q", path, expected, actual)
+		}
+	}
+}
+
 func TestAESGCMBarrier_MoveIntegrityV2(t *testing.T) {
 	inm, err := inmem.NewInmem(nil, logger)
 	if err != nil {
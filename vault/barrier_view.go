@@ -19,6 +19,7 @@ import (
 // BarrierView implements logical.Storage so it can be passed in as the
 // durable storage mechanism for logical views.
 type BarrierView struct {
+	barrier         logical.Storage
 	storage         *logical.StorageView
 	readOnlyErr     error
 	readOnlyErrLock sync.RWMutex
@@ -29,6 +30,7 @@ type BarrierView struct {
 // a view of it that can only operate with the given prefix.
 func NewBarrierView(barrier logical.Storage, prefix string) *BarrierView {
 	return &BarrierView{
+		barrier: barrier,
 		storage: logical.NewStorageView(barrier, prefix),
 	}
 }
@@ -96,8 +98,66 @@ func (v *BarrierView) Delete(ctx context.Context, key string) error {
 // SubView constructs a nested sub-view using the given prefix
 func (v *BarrierView) SubView(prefix string) *BarrierView {
 	return &BarrierView{
+		barrier:     v.barrier,
 		storage:     v.storage.SubView(prefix),
 		readOnlyErr: v.getReadOnlyErr(),
 		iCheck:      v.iCheck,
 	}
 }
+
+// barrierViewEntry is a storage entry to be written to a particular view.
+type barrierViewEntry struct {
+	view  *BarrierView
+	entry *logical.StorageEntry
+}
+
+// putBarrierViewEntries writes entries that may belong to different views. If
+// all of the views are backed by the same transactional barrier the entries
+// are written in a single transaction, otherwise they are written one at a
+// time in the given order, so callers should order entries as they would for
+// sequential writes.
+func putBarrierViewEntries(ctx context.Context, entries []barrierViewEntry) error {
+	var txnBarrier transactionalBarrier
+	expanded := make([]*logical.StorageEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.entry == nil {
+			return errors.New("cannot write nil entry")
+		}
+
+		tb, ok := e.view.barrier.(transactionalBarrier)
+		if !ok || (txnBarrier != nil && tb != txnBarrier) {
+			txnBarrier = nil
+			break
+		}
+		txnBarrier = tb
+
+		if err := e.view.storage.SanityCheck(e.entry.Key); err != nil {
+			return err
+		}
+
+		expandedKey := e.view.storage.ExpandKey(e.entry.Key)
+		roErr := e.view.getReadOnlyErr()
+		if roErr != nil {
+			if runICheck(e.view, expandedKey, roErr) {
+				return roErr
+			}
+		}
+
+		expanded = append(expanded, &logical.StorageEntry{
+			Key:      expandedKey,
+			Value:    e.entry.Value,
+			SealWrap: e.entry.SealWrap,
+		})
+	}
+
+	if txnBarrier == nil {
+		for _, e := range entries {
+			if err := e.view.Put(ctx, e.entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return txnBarrier.putEntries(ctx, expanded)
+}
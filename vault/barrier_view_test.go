@@ -5,11 +5,14 @@ package vault
 
 import (
 	"context"
+	"crypto/rand"
 	"reflect"
 	"sort"
 	"testing"
 
 	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/sdk/physical"
+	"github.com/hashicorp/vault/sdk/physical/inmem"
 )
 
 func TestBarrierView_impl(t *testing.T) {
@@ -319,3 +322,99 @@ func TestBarrierView_Readonly(t *testing.T) {
 		t.Fatalf("key test missing")
 	}
 }
+
+func TestBarrierView_PutEntries(t *testing.T) {
+	txnInm, err := inmem.NewTransactionalInmem(nil, logger)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	inm, err := inmem.NewInmem(nil, logger)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for name, backend := range map[string]physical.Backend{
+		"transactional":     txnInm,
+		"non-transactional": inm,
+	} {
+		t.Run(name, func(t *testing.T) {
+			barrier, err := NewAESGCMBarrier(backend, false)
+			if err != nil {
+				t.Fatalf("err: %v", err)
+			}
+			key, _ := barrier.GenerateKey(rand.Reader)
+			barrier.Initialize(context.Background(), key, nil, rand.Reader)
+			barrier.Unseal(context.Background(), key)
+
+			foo := NewBarrierView(barrier, "foo/")
+			bar := NewBarrierView(barrier, "bar/").SubView("baz/")
+			entries := []barrierViewEntry{
+				{view: foo, entry: &logical.StorageEntry{Key: "test", Value: []byte("foo")}},
+				{view: bar, entry: &logical.StorageEntry{Key: "test", Value: []byte("bar")}},
+			}
+			if err := putBarrierViewEntries(context.Background(), entries); err != nil {
+				t.Fatalf("err: %v", err)
+			}
+
+			for path, expected := range map[string]string{"foo/test": "foo", "bar/baz/test": "bar"} {
+				out, err := barrier.Get(context.Background(), path)
+				if err != nil {
+					t.Fatalf("err: %v", err)
+				}
+				if out == nil || string(out.Value) != expected {
+					t.Fatalf("bad entry at %q: %#v", path, out)
+				}
+			}
+
+			// Nothing is written when one of the entries is rejected
+			bar.setReadOnlyErr(logical.ErrReadOnly)
+			entries = []barrierViewEntry{
+				{view: bar, entry: &logical.StorageEntry{Key: "other", Value: []byte("bar")}},
+			}
+			if err := putBarrierViewEntries(context.Background(), entries); err != logical.ErrReadOnly {
+				t.Fatalf("err: %v", err)
+			}
+			if out, err := bar.Get(context.Background(), "other"); err != nil || out != nil {
+				t.Fatalf("unexpected entry: %#v, err: %v", out, err)
+			}
+		})
+	}
+}
+
+// TestBarrierView_PutEntries_Atomic tests that when a transactional write
+// fails partway through a batch, none of the entries are stored.
+func TestBarrierView_PutEntries_Atomic(t *testing.T) {
+	backend, err := inmem.NewTransactionalInmem(map[string]string{"max_value_size": "4096"}, logger)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	barrier, err := NewAESGCMBarrier(backend, false)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	key, _ := barrier.GenerateKey(rand.Reader)
+	barrier.Initialize(context.Background(), key, nil, rand.Reader)
+	barrier.Unseal(context.Background(), key)
+
+	foo := NewBarrierView(barrier, "foo/")
+	bar := NewBarrierView(barrier, "bar/")
+	entries := []barrierViewEntry{
+		{view: foo, entry: &logical.StorageEntry{Key: "first", Value: []byte("foo")}},
+		{view: bar, entry: &logical.StorageEntry{Key: "second", Value: []byte("bar")}},
+		{view: bar, entry: &logical.StorageEntry{Key: "too-large", Value: make([]byte, 8192)}},
+		{view: foo, entry: &logical.StorageEntry{Key: "last", Value: []byte("foo")}},
+	}
+	if err := putBarrierViewEntries(context.Background(), entries); err == nil {
+		t.Fatal("expected an error writing an oversized entry")
+	}
+
+	for _, path := range []string{"foo/first", "bar/second", "bar/too-large", "foo/last"} {
+		out, err := barrier.Get(context.Background(), path)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if out != nil {
+			t.Fatalf("unexpected entry at %q after a failed write", path)
+		}
+	}
+}
@@ -5,7 +5,9 @@ package vault
 
 import (
 	"context"
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/vault/helper/namespace"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -25,6 +27,92 @@ func (c *Core) CapabilitiesAndSubscribeEventTypes(ctx context.Context, token, pa
 		return nil, nil, &logical.StatusBadRequest{Err: "missing path"}
 	}
 
+	tokenCtx, policies, err := c.capabilitiesPolicies(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(policies) == 0 {
+		return []string{DenyCapability}, nil, nil
+	}
+
+	acl, err := NewACL(tokenCtx, policies)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to construct ACL: %w", err)
+	}
+
+	capabilities, eventTypes := acl.CapabilitiesAndSubscribeEventTypes(ctx, path)
+	sort.Strings(capabilities)
+	return capabilities, eventTypes, nil
+}
+
+// PolicyCapabilities describes the capabilities a single policy grants on a
+// path, and the path rule in that policy they come from.
+type PolicyCapabilities struct {
+	Policy       string
+	Namespace    string
+	Rule         string
+	Capabilities []string
+}
+
+// ExplainCapabilities returns, for each policy attached to the given token
+// that has a rule matching path, the capabilities that policy grants on its
+// own. Policies without a matching rule are omitted. The capabilities
+// returned by Capabilities are the union of these, unless one of them is
+// deny.
+func (c *Core) ExplainCapabilities(ctx context.Context, token, path string) ([]*PolicyCapabilities, error) {
+	if path == "" {
+		return nil, &logical.StatusBadRequest{Err: "missing path"}
+	}
+
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenCtx, policies, err := c.capabilitiesPolicies(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	fullPath := strings.TrimLeft(ns.Path+path, "/")
+	ret := make([]*PolicyCapabilities, 0, len(policies))
+	for _, policy := range policies {
+		acl, err := NewACL(tokenCtx, []*Policy{policy})
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct ACL for policy %q: %w", policy.Name, err)
+		}
+
+		explanation := &PolicyCapabilities{
+			Policy: policy.Name,
+		}
+		if policy.namespace != nil {
+			explanation.Namespace = policy.namespace.Path
+		}
+
+		if acl.root {
+			explanation.Capabilities = []string{RootCapability}
+			ret = append(ret, explanation)
+			continue
+		}
+
+		// Use list to mirror the fallback behavior of Capabilities
+		permissions, rule := acl.matchingRule(fullPath, logical.ListOperation)
+		if permissions == nil {
+			continue
+		}
+		explanation.Rule = strings.TrimPrefix(rule, explanation.Namespace)
+		explanation.Capabilities = capabilitiesFromBitmap(permissions.CapabilitiesBitmap)
+		sort.Strings(explanation.Capabilities)
+		ret = append(ret, explanation)
+	}
+
+	return ret, nil
+}
+
+// capabilitiesPolicies returns the policies attached to the given token,
+// including those derived from its entity, along with a context for the
+// token's namespace in which to evaluate them.
+func (c *Core) capabilitiesPolicies(ctx context.Context, token string) (context.Context, []*Policy, error) {
 	if token == "" {
 		return nil, nil, &logical.StatusBadRequest{Err: "missing token"}
 	}
@@ -80,19 +168,15 @@ func (c *Core) CapabilitiesAndSubscribeEventTypes(ctx context.Context, token, pa
 		policyCount++
 	}
 
+	// ACL construction should be performed on the token's namespace.
+	tokenCtx := namespace.ContextWithNamespace(ctx, tokenNS)
 	if policyCount == 0 {
-		return []string{DenyCapability}, nil, nil
+		return tokenCtx, nil, nil
 	}
 
-	// Construct the corresponding ACL object. ACL construction should be
-	// performed on the token's namespace.
-	tokenCtx := namespace.ContextWithNamespace(ctx, tokenNS)
-	acl, err := c.policyStore.ACL(tokenCtx, entity, policyNames, policies...)
+	policies, err = c.policyStore.resolvePolicies(tokenCtx, entity, policyNames, policies...)
 	if err != nil {
 		return nil, nil, err
 	}
-
-	capabilities, eventTypes := acl.CapabilitiesAndSubscribeEventTypes(ctx, path)
-	sort.Strings(capabilities)
-	return capabilities, eventTypes, nil
+	return tokenCtx, policies, nil
 }
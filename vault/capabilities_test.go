@@ -229,3 +229,83 @@ func TestCapabilities(t *testing.T) {
 		t.Fatalf("bad: got\n%#v\nexpected\n%#v\n", actual, expected)
 	}
 }
+
+func TestCapabilities_Explain(t *testing.T) {
+	c, _, _ := TestCoreUnsealed(t)
+	ctx := namespace.RootContext(nil)
+
+	policies := []string{`
+name = "broad"
+path "secret/*" {
+	capabilities = ["read", "list"]
+}
+path "secret/team-a/+/config" {
+	capabilities = ["update"]
+}
+`, `
+name = "exception"
+path "secret/team-a/private" {
+	capabilities = ["deny"]
+}
+`, `
+name = "unrelated"
+path "auth/*" {
+	capabilities = ["read"]
+}
+`}
+	for _, raw := range policies {
+		policy, err := ParseACLPolicy(namespace.RootNamespace, raw)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if err := c.policyStore.SetPolicy(ctx, policy); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	testMakeTokenDirectly(t, c.tokenStore, &logical.TokenEntry{
+		ID:       "explaintoken",
+		Path:     "auth/token/create",
+		Policies: []string{"broad", "exception", "unrelated"},
+		TTL:      time.Hour,
+	})
+
+	tCases := []struct {
+		path     string
+		expected []*PolicyCapabilities
+	}{
+		{
+			"secret/foo",
+			[]*PolicyCapabilities{
+				{Policy: "broad", Rule: "secret/*", Capabilities: []string{"list", "read"}},
+			},
+		},
+		{
+			"secret/team-a/app/config",
+			[]*PolicyCapabilities{
+				{Policy: "broad", Rule: "secret/team-a/+/config", Capabilities: []string{"update"}},
+			},
+		},
+		{
+			"secret/team-a/private",
+			[]*PolicyCapabilities{
+				{Policy: "broad", Rule: "secret/*", Capabilities: []string{"list", "read"}},
+				{Policy: "exception", Rule: "secret/team-a/private", Capabilities: []string{"deny"}},
+			},
+		},
+		{
+			"sys/mounts",
+			[]*PolicyCapabilities{},
+		},
+	}
+	for _, tCase := range tCases {
+		actual, err := c.ExplainCapabilities(ctx, "explaintoken", tCase.path)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		sort.Slice(actual, func(i, j int) bool { return actual[i].Policy < actual[j].Policy })
+		if !reflect.DeepEqual(actual, tCase.expected) {
+			t.Fatalf("bad: path %q: got\n%#v\nexpected\n%#v\n", tCase.path, actual, tCase.expected)
+		}
+	}
+}
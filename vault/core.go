@@ -381,6 +381,11 @@ type Core struct {
 	// against their migration ids
 	mountMigrationTracker *sync.Map
 
+	// mountTablesUnsharded records the mount table storage paths known to
+	// have no shards stored, so writes of unsharded tables can skip looking
+	// for stale shards
+	mountTablesUnsharded *sync.Map
+
 	// auth is loaded after unseal since it is a protected
 	// configuration
 	auth *MountTable
@@ -439,6 +444,13 @@ type Core struct {
 	// metricsCh is used to stop the metrics streaming
 	metricsCh chan struct{}
 
+	// mountDeletionCh is used to stop removing mounts pending deletion
+	mountDeletionCh chan struct{}
+
+	// mountDeletionGracePeriod is how long disabled secrets engines are kept,
+	// pending deletion, unless they have their own grace period
+	mountDeletionGracePeriod time.Duration
+
 	// metricsMutex is used to prevent a race condition between
 	// metrics emission and sealing leading to a nil pointer
 	metricsMutex sync.Mutex
@@ -446,6 +458,9 @@ type Core struct {
 	// inFlightReqMap is used to store info about in-flight requests
 	inFlightReqData *InFlightRequests
 
+	// tokenUsage tracks per-token request rates to flag sudden spikes
+	tokenUsage *tokenUsageTracker
+
 	// mfaResponseAuthQueue is used to cache the auth response per request ID
 	mfaResponseAuthQueue     *LoginMFAPriorityQueue
 	mfaResponseAuthQueueLock sync.Mutex
@@ -561,6 +576,10 @@ type Core struct {
 	// pluginFilePermissions is the permissions of the plugin files and directory
 	pluginFilePermissions int
 
+	// pluginDownloadPGPKeys are the PGP keys trusted to sign plugins that
+	// are registered by download URL
+	pluginDownloadPGPKeys []string
+
 	// pluginCatalog is used to manage plugin configurations
 	pluginCatalog *plugincatalog.PluginCatalog
 
@@ -762,6 +781,13 @@ func (c *Core) HALock() sync.Locker {
 	return c.stateLock.RLocker()
 }
 
+// StateTransitionStarted returns when the state lock was last acquired for
+// writing, i.e. when the core started unsealing, sealing or changing HA state,
+// or the zero time if no such transition is in progress.
+func (c *Core) StateTransitionStarted() time.Time {
+	return c.stateLock.WriteLockedSince()
+}
+
 // CoreConfig is used to parameterize a core
 type CoreConfig struct {
 	entCoreConfig
@@ -805,6 +831,9 @@ type CoreConfig struct {
 	// If any role based quota (LCQ or RLQ) is enabled, don't track lease counts by role
 	ImpreciseLeaseRoleTracking bool
 
+	// Encrypt new barrier entries with keys derived per storage prefix
+	BarrierPrefixKeyDerivation bool
+
 	// Disables the trace display for Sentinel checks
 	DisableSentinelTrace bool
 
@@ -846,6 +875,13 @@ type CoreConfig struct {
 
 	PluginFilePermissions int
 
+	PluginDownloadPGPKeys []string
+
+	// MountDeletionGracePeriod is how long disabled secrets engines are kept,
+	// pending deletion, unless they have their own grace period. Zero removes
+	// them immediately.
+	MountDeletionGracePeriod time.Duration
+
 	DisableSealWrap bool
 
 	RawConfig *server.Config
@@ -1074,6 +1110,7 @@ func CreateCore(conf *CoreConfig) (*Core, error) {
 		enableResponseHeaderHostname:   conf.EnableResponseHeaderHostname,
 		enableResponseHeaderRaftNodeID: conf.EnableResponseHeaderRaftNodeID,
 		mountMigrationTracker:          &sync.Map{},
+		mountTablesUnsharded:           &sync.Map{},
 		disableSSCTokens:               conf.DisableSSCTokens,
 		effectiveSDKVersion:            effectiveSDKVersion,
 		userFailedLoginInfo:            make(map[FailedLoginUser]*FailedLoginInfo),
@@ -1108,6 +1145,9 @@ func CreateCore(conf *CoreConfig) (*Core, error) {
 		InFlightReqCount: uberAtomic.NewUint64(0),
 	}
 
+	c.tokenUsage = newTokenUsageTracker(c.logger.Named("token-usage"), c.metricSink, c.sendCoreEvent)
+	c.allLoggers = append(c.allLoggers, c.tokenUsage.logger)
+
 	c.SetConfig(conf.RawConfig)
 
 	atomic.StoreUint32(c.replicationState, uint32(consts.ReplicationDRDisabled|consts.ReplicationPerformanceDisabled))
@@ -1205,10 +1245,15 @@ func NewCore(conf *CoreConfig) (*Core, error) {
 	if detectDeadlocks {
 		c.Logger().Debug("enabling deadlock detection for the barrier")
 	}
-	c.barrier, err = NewAESGCMBarrier(c.physical, detectDeadlocks)
+	barrier, err := NewAESGCMBarrier(c.physical, detectDeadlocks)
 	if err != nil {
 		return nil, fmt.Errorf("barrier setup failed: %w", err)
 	}
+	barrier.prefixKeyDerivation = conf.BarrierPrefixKeyDerivation
+	if conf.BarrierPrefixKeyDerivation {
+		c.Logger().Warn("barrier prefix key derivation is enabled: entries written from now on can't be read by Vault versions without support for it, even if it's disabled again")
+	}
+	c.barrier = barrier
 
 	err = c.entCheckStoredLicense(conf)
 	if err != nil {
@@ -1260,6 +1305,8 @@ func NewCore(conf *CoreConfig) (*Core, error) {
 	if conf.PluginFilePermissions != 0 {
 		c.pluginFilePermissions = conf.PluginFilePermissions
 	}
+	c.pluginDownloadPGPKeys = conf.PluginDownloadPGPKeys
+	c.mountDeletionGracePeriod = conf.MountDeletionGracePeriod
 
 	// Create secondaries (this will only impact Enterprise versions of Vault)
 	c.createSecondaries(conf.Logger)
@@ -2507,6 +2554,9 @@ func (s standardUnsealStrategy) unseal(ctx context.Context, logger log.Logger, c
 	c.metricsCh = make(chan struct{})
 	go c.emitMetricsActiveNode(c.metricsCh)
 
+	c.mountDeletionCh = make(chan struct{})
+	go c.mountDeletionLoop(c.mountDeletionCh)
+
 	// Establish version timestamps at the end of unseal on active nodes only.
 	if err := c.handleVersionTimeStamps(ctx); err != nil {
 		return err
@@ -2541,6 +2591,7 @@ func (c *Core) setupPluginCatalog(ctx context.Context) error {
 		Tmpdir:               c.pluginTmpdir,
 		EnableMlock:          c.enableMlock,
 		PluginRuntimeCatalog: c.pluginRuntimeCatalog,
+		DownloadPGPKeys:      c.pluginDownloadPGPKeys,
 	})
 	if err != nil {
 		return err
@@ -2837,6 +2888,11 @@ func (c *Core) preSeal() error {
 		c.metricsCh = nil
 	}
 
+	if c.mountDeletionCh != nil {
+		close(c.mountDeletionCh)
+		c.mountDeletionCh = nil
+	}
+
 	var result error
 
 	c.stopForwarding()
@@ -243,6 +243,16 @@ func (c *Core) leaseExpiryGaugeCollector(ctx context.Context) ([]metricsutil.Gau
 	return e.leaseAggregationMetrics(ctx, metricsConsts)
 }
 
+func (c *Core) leaseMountGaugeCollector(ctx context.Context) ([]metricsutil.GaugeLabelValues, error) {
+	c.stateLock.RLock()
+	e := c.expiration
+	c.stateLock.RUnlock()
+	if e == nil {
+		return []metricsutil.GaugeLabelValues{}, errors.New("nil expiration manager")
+	}
+	return e.leaseMountMetrics(ctx)
+}
+
 func (c *Core) tokenGaugeMethodCollector(ctx context.Context) ([]metricsutil.GaugeLabelValues, error) {
 	if c.IsDRSecondary() {
 		// there is no expiration manager on DR Secondaries
@@ -303,6 +313,12 @@ func (c *Core) emitMetricsActiveNode(stopCh chan struct{}) {
 			c.leaseExpiryGaugeCollector,
 			"",
 		},
+		{
+			[]string{"expire", "leases", "by_mount"},
+			[]metrics.Label{{"gauge", "leases_by_mount"}},
+			c.leaseMountGaugeCollector,
+			"",
+		},
 		{
 			[]string{"token", "count", "by_auth"},
 			[]metrics.Label{{"gauge", "token_by_auth"}},
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/vault/eventbus"
+)
+
+// Event types sent by core itself, rather than by plugins.
+const (
+	eventTypeLeaseCreate  logical.EventType = "lease/create"
+	eventTypeLeaseRevoke  logical.EventType = "lease/revoke"
+	eventTypeMountEnable  logical.EventType = "mount/enable"
+	eventTypeMountDisable logical.EventType = "mount/disable"
+)
+
+// sendCoreEvent sends an event generated by core to subscribers in the given
+// namespace. The event metadata is built from metadataPairs as with
+// logical.SendEvent. Failures are only logged, since events are best effort
+// and must never fail the operation that generated them.
+func (c *Core) sendCoreEvent(ns *namespace.Namespace, eventType logical.EventType, metadataPairs ...string) {
+	if c.events == nil || ns == nil {
+		return
+	}
+
+	sender, err := c.events.WithPlugin(ns, nil)
+	if err == nil {
+		err = logical.SendEvent(context.Background(), sender, string(eventType), metadataPairs...)
+	}
+	if err != nil && !errors.Is(err, eventbus.ErrNotStarted) {
+		c.logger.Warn("failed to send event", "event_type", eventType, "error", err)
+	}
+}
+
+// sendLeaseEvent sends an event about a secret lease. Leases backing tokens
+// are skipped, since they are created and revoked with every login.
+func (c *Core) sendLeaseEvent(le *leaseEntry, eventType logical.EventType) {
+	if le == nil || le.Secret == nil {
+		return
+	}
+
+	c.sendCoreEvent(le.namespace, eventType,
+		logical.EventMetadataOperation, string(eventType),
+		logical.EventMetadataDataPath, le.Path,
+		"lease_id", le.LeaseID,
+	)
+}
+
+// sendMountEvent sends an event about a secrets engine or auth method being
+// enabled or disabled. The data path is the sys path that manages the mount,
+// e.g. sys/mounts/secret/ or sys/auth/userpass/.
+func (c *Core) sendMountEvent(entry *MountEntry, eventType logical.EventType) {
+	if entry == nil {
+		return
+	}
+
+	dataPath := "sys/mounts/" + entry.Path
+	mountClass := "secret"
+	if entry.Table == credentialTableType {
+		dataPath = "sys/auth/" + entry.Path
+		mountClass = "auth"
+	}
+
+	c.sendCoreEvent(entry.Namespace(), eventType,
+		logical.EventMetadataOperation, string(eventType),
+		logical.EventMetadataDataPath, dataPath,
+		logical.EventMetadataModified, "true",
+		"path", entry.Path,
+		"type", entry.Type,
+		"mount_class", mountClass,
+		"mount_accessor", entry.Accessor,
+	)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func receiveCoreEvent(t *testing.T, ch <-chan *eventlogger.Event) *logical.EventReceived {
+	t.Helper()
+
+	select {
+	case e := <-ch:
+		return e.Payload.(*logical.EventReceived)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+	return nil
+}
+
+func coreEventMetadata(event *logical.EventReceived, key string) string {
+	return event.Event.Metadata.Fields[key].GetStringValue()
+}
+
+func TestCoreEvents_Mount(t *testing.T) {
+	c, _, _ := TestCoreUnsealed(t)
+	ctx := namespace.RootContext(nil)
+
+	ch, cancel, err := c.events.Subscribe(ctx, namespace.RootNamespace, "mount/*", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	me := &MountEntry{
+		Table: mountTableType,
+		Path:  "foo/",
+		Type:  "kv",
+	}
+	if err := c.mount(ctx, me); err != nil {
+		t.Fatal(err)
+	}
+
+	event := receiveCoreEvent(t, ch)
+	if event.EventType != string(eventTypeMountEnable) {
+		t.Fatalf("bad event type: %q", event.EventType)
+	}
+	if v := coreEventMetadata(event, logical.EventMetadataDataPath); v != "sys/mounts/foo/" {
+		t.Fatalf("bad data path: %q", v)
+	}
+	if v := coreEventMetadata(event, "mount_accessor"); v != me.Accessor {
+		t.Fatalf("bad mount accessor: %q", v)
+	}
+
+	if err := c.unmount(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	event = receiveCoreEvent(t, ch)
+	if event.EventType != string(eventTypeMountDisable) {
+		t.Fatalf("bad event type: %q", event.EventType)
+	}
+	if v := coreEventMetadata(event, logical.EventMetadataDataPath); v != "sys/mounts/foo/" {
+		t.Fatalf("bad data path: %q", v)
+	}
+
+	me = &MountEntry{
+		Table: credentialTableType,
+		Path:  "foo/",
+		Type:  "noop",
+	}
+	c.credentialBackends["noop"] = func(ctx context.Context, config *logical.BackendConfig) (logical.Backend, error) {
+		return &NoopBackend{BackendType: logical.TypeCredential}, nil
+	}
+	if err := c.enableCredential(ctx, me); err != nil {
+		t.Fatal(err)
+	}
+
+	event = receiveCoreEvent(t, ch)
+	if event.EventType != string(eventTypeMountEnable) {
+		t.Fatalf("bad event type: %q", event.EventType)
+	}
+	if v := coreEventMetadata(event, logical.EventMetadataDataPath); v != "sys/auth/foo/" {
+		t.Fatalf("bad data path: %q", v)
+	}
+	if v := coreEventMetadata(event, "mount_class"); v != "auth" {
+		t.Fatalf("bad mount class: %q", v)
+	}
+}
+
+func TestCoreEvents_Lease(t *testing.T) {
+	c, _, _ := TestCoreUnsealed(t)
+	ctx := namespace.RootContext(nil)
+
+	ch, cancel, err := c.events.Subscribe(ctx, namespace.RootNamespace, "lease/*", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	noop := &NoopBackend{}
+	_, barrier, _ := mockBarrier(t)
+	view := NewBarrierView(barrier, "logical/")
+	meUUID, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.router.Mount(noop, "prod/aws/", &MountEntry{Path: "prod/aws/", Type: "noop", UUID: meUUID, Accessor: "noop-accessor", namespace: namespace.RootNamespace}, view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &logical.Request{
+		Operation:   logical.ReadOperation,
+		Path:        "prod/aws/foo",
+		ClientToken: "foobar",
+	}
+	req.SetTokenEntry(&logical.TokenEntry{ID: "foobar", NamespaceID: "root"})
+	resp := &logical.Response{
+		Secret: &logical.Secret{
+			LeaseOptions: logical.LeaseOptions{
+				TTL: time.Hour,
+			},
+		},
+	}
+
+	id, err := c.expiration.Register(ctx, req, resp, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := receiveCoreEvent(t, ch)
+	if event.EventType != string(eventTypeLeaseCreate) {
+		t.Fatalf("bad event type: %q", event.EventType)
+	}
+	if v := coreEventMetadata(event, "lease_id"); v != id {
+		t.Fatalf("bad lease ID: %q", v)
+	}
+	if v := coreEventMetadata(event, logical.EventMetadataDataPath); v != "prod/aws/foo" {
+		t.Fatalf("bad data path: %q", v)
+	}
+
+	if err := c.expiration.Revoke(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	event = receiveCoreEvent(t, ch)
+	if event.EventType != string(eventTypeLeaseRevoke) {
+		t.Fatalf("bad event type: %q", event.EventType)
+	}
+	if v := coreEventMetadata(event, "lease_id"); v != id {
+		t.Fatalf("bad lease ID: %q", v)
+	}
+}
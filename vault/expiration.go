@@ -63,6 +63,10 @@ const (
 	// maxLeaseThreshold is the maximum lease count before generating log warning
 	maxLeaseThreshold = 256000
 
+	// leaseRestoreProgressLogInterval is how often lease restore progress is
+	// logged
+	leaseRestoreProgressLogInterval = 10 * time.Second
+
 	// numExpirationWorkersDefault is the maximum amount of workers working on lease expiration
 	numExpirationWorkersDefault = 200
 
@@ -381,7 +385,7 @@ func NewExpirationManager(c *Core, view *BarrierView, e ExpireLeaseStrategy, log
 
 	if detectDeadlocks {
 		managerLogger.Debug("enabling deadlock detection")
-		exp.pendingLock = &locking.DeadlockRWMutex{}
+		exp.pendingLock = &locking.DeadlockRWMutex{Name: "expiration"}
 	}
 
 	go exp.uniquePoliciesGc()
@@ -571,7 +575,7 @@ func (m *ExpirationManager) Tidy(ctx context.Context) error {
 
 	// Create a cache to keep track of looked up tokens
 	tokenCache := make(map[string]bool)
-	var countLease, revokedCount, deletedCountInvalidToken, deletedCountEmptyToken int64
+	var countLease, revokedCount, deletedCountInvalidToken, deletedCountEmptyToken, skippedCountIrrevocable int64
 
 	tidyFunc := func(leaseID string) {
 		countLease++
@@ -590,6 +594,15 @@ func (m *ExpirationManager) Tidy(ctx context.Context) error {
 			return
 		}
 
+		// Revocation of irrevocable leases has already failed permanently, so
+		// retrying it here would only slow tidy down. They remain listed by
+		// sys/leases until they are force revoked.
+		if le.isIrrevocable() {
+			logger.Debug("skipping irrevocable lease", "lease_id", leaseID)
+			skippedCountIrrevocable++
+			return
+		}
+
 		var isValid, ok bool
 		revokeLease := false
 		if le.ClientToken == "" {
@@ -662,6 +675,7 @@ func (m *ExpirationManager) Tidy(ctx context.Context) error {
 	logger.Info("number of leases which had empty tokens", "count", deletedCountEmptyToken)
 	logger.Info("number of leases which had invalid tokens", "count", deletedCountInvalidToken)
 	logger.Info("number of leases successfully revoked", "count", revokedCount)
+	logger.Info("number of irrevocable leases skipped", "count", skippedCountIrrevocable)
 
 	return tidyErrors.ErrorOrNil()
 }
@@ -676,6 +690,11 @@ func (m *ExpirationManager) Restore(errorFunc func()) (retErr error) {
 		// Stop() function to shut everything down.
 		atomic.StoreInt32(m.restoreMode, 0)
 
+		// Don't leave the gauge reporting leases that will never be restored
+		if retErr != nil {
+			metrics.SetGauge([]string{"expire", "restore", "remaining"}, 0)
+		}
+
 		switch {
 		case retErr == nil:
 		case strings.Contains(retErr.Error(), context.Canceled.Error()):
@@ -702,6 +721,12 @@ func (m *ExpirationManager) Restore(errorFunc func()) (retErr error) {
 	}
 	m.logger.Debug("leases collected", "num_existing", leaseCount)
 
+	// Report restore progress, so operators can tell how far along a
+	// background restore of a large number of leases is
+	restoreStart := time.Now()
+	metrics.SetGauge([]string{"expire", "restore", "total"}, float32(leaseCount))
+	metrics.SetGauge([]string{"expire", "restore", "remaining"}, float32(leaseCount))
+
 	// Make the channels used for the worker pool
 	type lease struct {
 		namespace *namespace.Namespace
@@ -784,6 +809,7 @@ func (m *ExpirationManager) Restore(errorFunc func()) (retErr error) {
 	}()
 
 	// Ensure all keys on the chan are processed
+	lastProgressLog := restoreStart
 LOOP:
 	for i := 0; i < leaseCount; i++ {
 		select {
@@ -797,6 +823,15 @@ LOOP:
 			break LOOP
 
 		case <-result:
+			restored := i + 1
+			if restored%500 == 0 {
+				metrics.SetGauge([]string{"expire", "restore", "remaining"}, float32(leaseCount-restored))
+			}
+			if time.Since(lastProgressLog) >= leaseRestoreProgressLogInterval {
+				lastProgressLog = time.Now()
+				m.logger.Info("restoring leases", "progress", restored, "total", leaseCount,
+					"percent_complete", float64(restored)/float64(leaseCount)*100)
+			}
 		}
 	}
 
@@ -815,7 +850,9 @@ LOOP:
 	m.restoreLocks = nil
 	m.restoreModeLock.Unlock()
 
-	m.logger.Info("lease restore complete")
+	metrics.SetGauge([]string{"expire", "restore", "remaining"}, 0)
+	metrics.MeasureSince([]string{"expire", "restore"}, restoreStart)
+	m.logger.Info("lease restore complete", "num_restored", leaseCount, "duration", time.Since(restoreStart))
 	return nil
 }
 
@@ -1076,6 +1113,7 @@ func (m *ExpirationManager) revokeCommon(ctx context.Context, leaseID string, fo
 		}
 		m.logger.Warn("finished revoking incorrectly non-expiring lease", "leaseID", le.LeaseID, "accessor", accessor)
 	}
+	m.core.sendLeaseEvent(le, eventTypeLeaseRevoke)
 	return nil
 }
 
@@ -1572,6 +1610,8 @@ func (m *ExpirationManager) Register(ctx context.Context, req *logical.Request,
 	// microseconds. This provides a nicer UX.
 	resp.Secret.TTL = le.ExpireTime.Sub(time.Now()).Round(time.Second)
 
+	m.core.sendLeaseEvent(le, eventTypeLeaseCreate)
+
 	// Done
 	return le.LeaseID, nil
 }
@@ -2464,6 +2504,68 @@ func (m *ExpirationManager) leaseAggregationMetrics(ctx context.Context, consts
 	return flattenedResults, nil
 }
 
+// leaseMountMetrics counts the leases held under each mount, labeled by
+// namespace and mount point.
+func (m *ExpirationManager) leaseMountMetrics(ctx context.Context) ([]metricsutil.GaugeLabelValues, error) {
+	byMount := make(map[*MountEntry]int)
+	namespaces := make(map[string]*namespace.Namespace)
+
+	err := m.walkLeases(func(leaseID string, _ time.Time) bool {
+		select {
+		// Abort and return empty collection if it's taking too much time, nonblocking check.
+		case <-ctx.Done():
+			return false
+		default:
+		}
+
+		_, nsID := namespace.SplitIDFromString(leaseID)
+		if nsID == "" {
+			nsID = namespace.RootNamespaceID
+		}
+		ns, ok := namespaces[nsID]
+		if !ok {
+			// A failed lookup is cached as nil so the namespace is only
+			// resolved once per collection
+			ns, _ = m.core.NamespaceByID(ctx, nsID)
+			namespaces[nsID] = ns
+		}
+		if ns == nil {
+			return true
+		}
+
+		if entry := m.router.MatchingMountEntry(namespace.ContextWithNamespace(ctx, ns), leaseID); entry != nil {
+			byMount[entry] += 1
+		}
+		return true
+	})
+	if err != nil {
+		return []metricsutil.GaugeLabelValues{}, suppressRestoreModeError(err)
+	}
+
+	// If collection was cancelled, return an empty array.
+	select {
+	case <-ctx.Done():
+		return []metricsutil.GaugeLabelValues{}, nil
+	default:
+	}
+
+	values := make([]metricsutil.GaugeLabelValues, 0, len(byMount))
+	for entry, count := range byMount {
+		mountPoint := entry.Path
+		if entry.Table == credentialTableType {
+			mountPoint = credentialRoutePrefix + mountPoint
+		}
+		values = append(values, metricsutil.GaugeLabelValues{
+			Labels: []metrics.Label{
+				metricsutil.NamespaceLabel(entry.namespace),
+				{Name: "mount_point", Value: mountPoint},
+			},
+			Value: float32(count),
+		})
+	}
+	return values, nil
+}
+
 // Callback function type to walk tokens referenced in the expiration
 // manager. Don't want to use leaseEntry here because it's an unexported
 // type (though most likely we would only call this from within the "vault" core package.)
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"reflect"
 	"sort"
 	"strings"
@@ -233,6 +234,63 @@ func TestExpiration_Metrics(t *testing.T) {
 	}
 }
 
+// TestExpiration_LeaseMountMetrics verifies that leases are counted under the
+// mount they were issued from, and leases outside any mount are skipped.
+func TestExpiration_LeaseMountMetrics(t *testing.T) {
+	c, _, _ := TestCoreUnsealed(t)
+	exp := c.expiration
+
+	if err := exp.Restore(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	leaseIDs := []string{
+		"secret/foo/1",
+		"secret/foo/2",
+		"secret/bar/3",
+		"auth/token/create/4",
+		"unmounted/5",
+	}
+	for _, leaseID := range leaseIDs {
+		le := &leaseEntry{
+			LeaseID:    leaseID,
+			Path:       path.Dir(leaseID),
+			namespace:  namespace.RootNamespace,
+			IssueTime:  time.Now(),
+			ExpireTime: time.Now().Add(time.Hour),
+		}
+
+		exp.pendingLock.Lock()
+		if err := exp.persistEntry(namespace.RootContext(nil), le); err != nil {
+			exp.pendingLock.Unlock()
+			t.Fatalf("error persisting entry: %v", err)
+		}
+		exp.updatePendingInternal(le)
+		exp.pendingLock.Unlock()
+	}
+
+	values, err := exp.leaseMountMetrics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts := make(map[string]float32)
+	for _, v := range values {
+		if len(v.Labels) != 2 || v.Labels[0] != (metrics.Label{Name: "namespace", Value: "root"}) {
+			t.Fatalf("unexpected labels: %v", v.Labels)
+		}
+		counts[v.Labels[1].Value] = v.Value
+	}
+
+	expected := map[string]float32{
+		"secret/":     3,
+		"auth/token/": 1,
+	}
+	if !reflect.DeepEqual(expected, counts) {
+		t.Fatalf("bad: lease counts by mount; expected: %v actual: %v", expected, counts)
+	}
+}
+
 func TestExpiration_TotalLeaseCount(t *testing.T) {
 	// Quotas and internal lease count tracker are coupled, so this is a proxy
 	// for testing the total lease count quota
@@ -3138,6 +3196,38 @@ func TestExpiration_FetchLeaseTimesIrrevocable(t *testing.T) {
 	}
 }
 
+func TestExpiration_TidySkipsIrrevocable(t *testing.T) {
+	exp := mockExpiration(t)
+	ctx := namespace.RootContext(nil)
+
+	leaseID := registerOneLease(t, ctx, exp)
+	le, err := exp.loadEntry(ctx, leaseID)
+	if err != nil {
+		t.Fatalf("error loading lease: %v", err)
+	}
+	exp.pendingLock.Lock()
+	exp.markLeaseIrrevocable(ctx, le, fmt.Errorf("test irrevocable error"))
+	exp.pendingLock.Unlock()
+
+	// Give the lease an invalid token, which would otherwise get it revoked
+	le.ClientToken = "invalidtoken"
+	if err := exp.persistEntry(ctx, le); err != nil {
+		t.Fatalf("error persisting entry: %v", err)
+	}
+
+	if err := exp.Tidy(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	le, err = exp.loadEntry(ctx, leaseID)
+	if err != nil {
+		t.Fatalf("error loading lease: %v", err)
+	}
+	if le == nil || !le.isIrrevocable() {
+		t.Fatalf("expected irrevocable lease to be left alone, got: %#v", le)
+	}
+}
+
 func TestExpiration_StopClearsIrrevocableCache(t *testing.T) {
 	c, _, _ := TestCoreUnsealed(t)
 	exp := c.expiration
@@ -6,6 +6,7 @@
 package audit
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/vault/helper/testhelpers/minimal"
@@ -13,31 +14,30 @@ import (
 )
 
 // TestAuditFilteringInCE ensures that the audit device 'filter'
-// option is only supported in the enterprise edition of the product.
+// option is supported in the community edition of the product.
 func TestAuditFilteringInCE(t *testing.T) {
 	t.Parallel()
 	cluster := minimal.NewTestSoloCluster(t, nil)
 	client := cluster.Cores[0].Client
 
-	// Attempt to create an audit device with filtering enabled.
+	// Create an audit device with filtering enabled.
 	mountPointFilterDevicePath := "mountpoint"
 	mountPointFilterDeviceData := map[string]any{
 		"type":        "file",
 		"description": "",
 		"local":       false,
 		"options": map[string]any{
-			"file_path": "/tmp/audit.log",
+			"file_path": filepath.Join(t.TempDir(), "audit.log"),
 			"filter":    "mount_point == secret/",
 		},
 	}
 	_, err := client.Logical().Write("sys/audit/"+mountPointFilterDevicePath, mountPointFilterDeviceData)
-	require.Error(t, err)
-	require.ErrorContains(t, err, "enterprise-only options supplied")
+	require.NoError(t, err)
 
-	// Ensure the device has not been created.
+	// Ensure the device has been created.
 	devices, err := client.Sys().ListAudit()
 	require.NoError(t, err)
-	require.Len(t, devices, 0)
+	require.Len(t, devices, 1)
 }
 
 // TestAuditFilteringFallbackDeviceInCE validates that the audit device
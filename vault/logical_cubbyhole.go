@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/vault/helper/versions"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/consts"
@@ -89,7 +90,8 @@ func (b *CubbyholeBackend) paths() []*framework.Path {
 					DisplayAttrs: &framework.DisplayAttributes{
 						OperationVerb: "delete",
 					},
-					Summary: "Deletes the secret at the specified location.",
+					Summary:     "Deletes the secret at the specified location.",
+					Description: "If the recursive query parameter is true, the location is treated as a folder and all secrets beneath it are deleted.",
 				},
 				logical.ListOperation: &framework.PathOperation{
 					Callback: b.handleList,
@@ -209,6 +211,28 @@ func (b *CubbyholeBackend) handleDelete(ctx context.Context, req *logical.Reques
 
 	path := data.Get("path").(string)
 
+	// The cubbyhole takes arbitrary input, so recursive isn't part of the
+	// schema, where it would be validated on writes too
+	recursive := false
+	if raw, ok := req.Data["recursive"]; ok {
+		var err error
+		if recursive, err = parseutil.ParseBool(raw); err != nil {
+			return logical.ErrorResponse("invalid value for recursive: %s", err), logical.ErrInvalidRequest
+		}
+	}
+
+	// A recursive delete removes everything beneath the path, which is
+	// treated as a folder, or beneath the cubbyhole root
+	if recursive {
+		if path != "" && !strings.HasSuffix(path, "/") {
+			path += "/"
+		}
+		if err := logical.ClearView(ctx, logical.NewStorageView(req.Storage, req.ClientToken+"/"+path)); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
 	// Delete the key at the request path
 	if err := req.Storage.Delete(ctx, req.ClientToken+"/"+path); err != nil {
 		return nil, err
@@ -115,6 +115,82 @@ func TestCubbyholeBackend_Delete(t *testing.T) {
 	}
 }
 
+func TestCubbyholeBackend_DeleteFolder(t *testing.T) {
+	b := testCubbyholeBackend()
+	storage := &logical.InmemStorage{}
+	clientToken, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherToken, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(token, path string) {
+		t.Helper()
+		req := logical.TestRequest(t, logical.UpdateOperation, path)
+		req.Data["raw"] = "test"
+		req.Storage = storage
+		req.ClientToken = token
+		if _, err := b.HandleRequest(context.Background(), req); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+	list := func(token string) []string {
+		t.Helper()
+		req := logical.TestRequest(t, logical.ListOperation, "")
+		req.Storage = storage
+		req.ClientToken = token
+		resp, err := b.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		keys, _ := resp.Data["keys"].([]string)
+		sort.Strings(keys)
+		return keys
+	}
+	del := func(path string, recursive bool) {
+		t.Helper()
+		req := logical.TestRequest(t, logical.DeleteOperation, path)
+		if recursive {
+			req.Data["recursive"] = "true"
+		}
+		req.Storage = storage
+		req.ClientToken = clientToken
+		if _, err := b.HandleRequest(context.Background(), req); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	write(clientToken, "foo")
+	write(clientToken, "dir/bar")
+	write(clientToken, "dir/nested/baz")
+	write(otherToken, "foo")
+
+	// Without recursive, deleting a folder or the root is a no-op
+	del("dir/", false)
+	del("", false)
+	if keys := list(clientToken); !reflect.DeepEqual(keys, []string{"dir/", "foo"}) {
+		t.Fatalf("bad keys after non-recursive delete: %#v", keys)
+	}
+
+	del("dir", true)
+	if keys := list(clientToken); !reflect.DeepEqual(keys, []string{"foo"}) {
+		t.Fatalf("bad keys after deleting folder: %#v", keys)
+	}
+
+	del("", true)
+	if keys := list(clientToken); len(keys) != 0 {
+		t.Fatalf("bad keys after deleting cubbyhole root: %#v", keys)
+	}
+
+	// Other tokens' cubbyholes must be untouched
+	if keys := list(otherToken); !reflect.DeepEqual(keys, []string{"foo"}) {
+		t.Fatalf("bad keys for other token: %#v", keys)
+	}
+}
+
 func TestCubbyholeBackend_List(t *testing.T) {
 	b := testCubbyholeBackend()
 	req := logical.TestRequest(t, logical.UpdateOperation, "foo")
@@ -14,6 +14,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/helper/compressutil"
+	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
@@ -63,6 +64,11 @@ func (b *RawBackend) handleRawRead(ctx context.Context, req *logical.Request, da
 		return logical.ErrorResponse("invalid encoding %q", encoding), logical.ErrInvalidRequest
 	}
 
+	subkeys := data.Get("subkeys").(bool)
+	if subkeys && encoding != "" {
+		return logical.ErrorResponse("encoding cannot be used with subkeys"), logical.ErrInvalidRequest
+	}
+
 	if b.recoveryMode {
 		b.logger.Info("reading", "path", path)
 	}
@@ -106,6 +112,19 @@ func (b *RawBackend) handleRawRead(ctx context.Context, req *logical.Request, da
 		}
 	}
 
+	if subkeys {
+		var parsed map[string]interface{}
+		if err := jsonutil.DecodeJSON(valueBytes, &parsed); err != nil {
+			return logical.ErrorResponse("value at %q is not a JSON object", path), logical.ErrInvalidRequest
+		}
+
+		return &logical.Response{
+			Data: map[string]interface{}{
+				"subkeys": rawSubkeys(parsed),
+			},
+		}, nil
+	}
+
 	var value interface{} = string(valueBytes)
 	// Golang docs (https://pkg.go.dev/encoding/json#Marshal), []byte encodes as a base64-encoded string
 	if encoding == "base64" {
@@ -120,6 +139,21 @@ func (b *RawBackend) handleRawRead(ctx context.Context, req *logical.Request, da
 	return resp, nil
 }
 
+// rawSubkeys returns the structure of m with every non-object value replaced
+// by nil, so the shape of an entry can be inspected without exposing its
+// contents.
+func rawSubkeys(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if nested, ok := v.(map[string]interface{}); ok {
+			out[k] = rawSubkeys(nested)
+			continue
+		}
+		out[k] = nil
+	}
+	return out
+}
+
 // handleRawWrite is used to write directly to the barrier
 func (b *RawBackend) handleRawWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 	path := data.Get("path").(string)
@@ -314,6 +348,9 @@ func rawPaths(prefix string, r *RawBackend) []*framework.Path {
 				"compression_type": {
 					Type: framework.TypeString,
 				},
+				"subkeys": {
+					Type: framework.TypeBool,
+				},
 			},
 
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -328,8 +365,10 @@ func rawPaths(prefix string, r *RawBackend) []*framework.Path {
 							Description: "OK",
 							Fields: map[string]*framework.FieldSchema{
 								"value": {
-									Type:     framework.TypeString,
-									Required: true,
+									Type: framework.TypeString,
+								},
+								"subkeys": {
+									Type: framework.TypeMap,
 								},
 							},
 						}},
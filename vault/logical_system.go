@@ -109,6 +109,7 @@ func NewSystemBackend(core *Core, logger log.Logger, config *logical.BackendConf
 			Root: []string{
 				"auth/*",
 				"remount",
+				"rollback/*",
 				"audit",
 				"audit/*",
 				"raw",
@@ -219,6 +220,7 @@ func NewSystemBackend(core *Core, logger log.Logger, config *logical.BackendConf
 	b.Backend.Paths = append(b.Backend.Paths, b.internalPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.pprofPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.remountPaths()...)
+	b.Backend.Paths = append(b.Backend.Paths, b.rollbackPaths()...)
 	b.Backend.Paths = append(b.Backend.Paths, b.metricsPath())
 	b.Backend.Paths = append(b.Backend.Paths, b.monitorPath())
 	b.Backend.Paths = append(b.Backend.Paths, b.inFlightRequestPath())
@@ -541,11 +543,28 @@ func (b *SystemBackend) handlePluginCatalogUpdate(ctx context.Context, _ *logica
 
 	command := d.Get("command").(string)
 	ociImage := d.Get("oci_image").(string)
+	downloadURL := d.Get("download_url").(string)
+	if downloadURL != "" {
+		if ociImage != "" {
+			return logical.ErrorResponse("download_url cannot be specified together with oci_image"), nil
+		}
+		if command != "" {
+			return logical.ErrorResponse("download_url cannot be specified together with command"), nil
+		}
+		// The file name is derived from the name and version, so registering
+		// a new version doesn't replace the binary of a version already
+		// registered.
+		command = pluginName
+		if pluginVersion != "" {
+			command = pluginName + "-" + pluginVersion
+		}
+	}
 	if command == "" && ociImage == "" {
 		return logical.ErrorResponse("must provide at least one of command or oci_image"), nil
 	}
 
-	if ociImage == "" {
+	// Downloaded binaries are written by Vault itself once they're verified
+	if ociImage == "" && downloadURL == "" {
 		if err = b.Core.CheckPluginPerms(command); err != nil {
 			return nil, err
 		}
@@ -586,21 +605,41 @@ func (b *SystemBackend) handlePluginCatalogUpdate(ctx context.Context, _ *logica
 		return logical.ErrorResponse("Could not decode SHA256 value from Hex %s: %s", sha256, err), err
 	}
 
+	var signature []byte
+	if downloadURL != "" {
+		signatureB64 := d.Get("signature").(string)
+		if signatureB64 == "" {
+			return logical.ErrorResponse("missing signature for download_url"), nil
+		}
+		signature, err = base64.StdEncoding.DecodeString(signatureB64)
+		if err != nil {
+			return logical.ErrorResponse("could not decode signature from base64: %s", err), nil
+		}
+	}
+
 	err = b.Core.pluginCatalog.Set(ctx, pluginutil.SetPluginInput{
-		Name:     pluginName,
-		Type:     pluginType,
-		Version:  pluginVersion,
-		OCIImage: ociImage,
-		Runtime:  pluginRuntime,
-		Command:  command,
-		Args:     args,
-		Env:      env,
-		Sha256:   sha256Bytes,
+		Name:              pluginName,
+		Type:              pluginType,
+		Version:           pluginVersion,
+		OCIImage:          ociImage,
+		Runtime:           pluginRuntime,
+		Command:           command,
+		Args:              args,
+		Env:               env,
+		Sha256:            sha256Bytes,
+		DownloadURL:       downloadURL,
+		DownloadSignature: signature,
 	})
 	if err != nil {
 		if errors.Is(err, plugincatalog.ErrPluginNotFound) ||
 			errors.Is(err, plugincatalog.ErrPluginVersionMismatch) ||
-			errors.Is(err, plugincatalog.ErrPluginUnableToRun) {
+			errors.Is(err, plugincatalog.ErrPluginUnableToRun) ||
+			errors.Is(err, plugincatalog.ErrDirectoryNotConfigured) ||
+			errors.Is(err, plugincatalog.ErrPluginDownloadNotConfigured) ||
+			errors.Is(err, plugincatalog.ErrPluginDownloadInvalid) ||
+			errors.Is(err, plugincatalog.ErrPluginDownloadFailed) ||
+			errors.Is(err, plugincatalog.ErrPluginDownloadVerification) ||
+			errors.Is(err, consts.ErrPathContainsParentReferences) {
 			return logical.ErrorResponse(err.Error()), nil
 		}
 
@@ -1245,6 +1284,9 @@ func (b *SystemBackend) handleCapabilities(ctx context.Context, req *logical.Req
 		return logical.ErrorResponse("paths must be supplied"), nil
 	}
 
+	explain := d.Get("explain").(bool)
+	explanations := make(map[string]interface{}, len(paths))
+
 	for _, path := range paths {
 		pathCap, err := b.Core.Capabilities(ctx, token, path)
 		if err != nil {
@@ -1254,6 +1296,27 @@ func (b *SystemBackend) handleCapabilities(ctx context.Context, req *logical.Req
 			return nil, err
 		}
 		ret.Data[path] = pathCap
+
+		if explain {
+			policyCaps, err := b.Core.ExplainCapabilities(ctx, token, path)
+			if err != nil {
+				return nil, err
+			}
+			pathExplanations := make([]map[string]interface{}, 0, len(policyCaps))
+			for _, pc := range policyCaps {
+				pathExplanations = append(pathExplanations, map[string]interface{}{
+					"policy":       pc.Policy,
+					"namespace":    pc.Namespace,
+					"rule":         pc.Rule,
+					"capabilities": pc.Capabilities,
+				})
+			}
+			explanations[path] = pathExplanations
+		}
+	}
+
+	if explain {
+		ret.Data["explain"] = explanations
 	}
 
 	// This is only here for backwards compatibility
@@ -1401,6 +1464,12 @@ func (b *SystemBackend) mountInfo(ctx context.Context, entry *MountEntry, legacy
 	if len(entry.Config.ListingVisibility) > 0 {
 		entryConfig["listing_visibility"] = entry.Config.ListingVisibility
 	}
+	if entry.Config.RollbackInterval > 0 {
+		entryConfig["rollback_interval"] = int64(entry.Config.RollbackInterval.Seconds())
+	}
+	if entry.Config.DeletionGracePeriod > 0 {
+		entryConfig["deletion_grace_period"] = int64(entry.Config.DeletionGracePeriod.Seconds())
+	}
 	if rawVal, ok := entry.synthesizedConfigCache.Load("passthrough_request_headers"); ok {
 		entryConfig["passthrough_request_headers"] = rawVal.([]string)
 	}
@@ -1429,6 +1498,10 @@ func (b *SystemBackend) mountInfo(ctx context.Context, entry *MountEntry, legacy
 		entryConfig["delegated_auth_accessors"] = rawVal.([]string)
 	}
 
+	if entry.MountState == mountStatePendingDeletion {
+		info["purge_time"] = time.Unix(entry.PurgeTime, 0).UTC().Format(time.RFC3339)
+	}
+
 	// Add deprecation status only if it exists
 	builtinType := b.Core.builtinTypeFromMountEntry(ctx, entry)
 	if status, ok := b.Core.builtinRegistry.DeprecationStatus(entry.Type, builtinType); ok {
@@ -1618,6 +1691,32 @@ func (b *SystemBackend) handleMount(ctx context.Context, req *logical.Request, d
 	}
 	config.ListingVisibility = apiConfig.ListingVisibility
 
+	if apiConfig.RollbackInterval != "" {
+		rollbackInterval, err := parseutil.ParseDurationSecond(apiConfig.RollbackInterval)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf(
+					"unable to parse rollback interval of %s: %s", apiConfig.RollbackInterval, err)),
+				logical.ErrInvalidRequest
+		}
+		if rollbackInterval < 0 {
+			return logical.ErrorResponse("rollback_interval cannot be negative"), logical.ErrInvalidRequest
+		}
+		config.RollbackInterval = rollbackInterval
+	}
+
+	if apiConfig.DeletionGracePeriod != "" {
+		gracePeriod, err := parseutil.ParseDurationSecond(apiConfig.DeletionGracePeriod)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf(
+					"unable to parse deletion grace period of %s: %s", apiConfig.DeletionGracePeriod, err)),
+				logical.ErrInvalidRequest
+		}
+		if gracePeriod < 0 {
+			return logical.ErrorResponse("deletion_grace_period cannot be negative"), logical.ErrInvalidRequest
+		}
+		config.DeletionGracePeriod = gracePeriod
+	}
+
 	if len(apiConfig.AuditNonHMACRequestKeys) > 0 {
 		config.AuditNonHMACRequestKeys = apiConfig.AuditNonHMACRequestKeys
 	}
@@ -1831,6 +1930,23 @@ func (b *SystemBackend) handleUnmount(ctx context.Context, req *logical.Request,
 		return handleError(fmt.Errorf("unable to find storage for path: %q", path))
 	}
 
+	// Mounts with a deletion grace period are only marked as pending deletion,
+	// so they can still be recovered. Unmounting a mount that is already
+	// pending deletion, or purging it, removes it right away.
+	if entry != nil && !data.Get("purge").(bool) && b.Core.mountDeletionGracePeriodFor(entry) > 0 &&
+		entry.MountState != mountStatePendingDeletion {
+		purgeTime, err := b.Core.markMountPendingDeletion(ctx, path)
+		if err != nil {
+			b.Backend.Logger().Error("marking mount as pending deletion failed", "path", path, "error", err)
+			return handleError(err)
+		}
+
+		resp := &logical.Response{}
+		resp.AddWarning(fmt.Sprintf("Mount %q is pending deletion and will be removed after %s. Until then it can be restored with sys/mounts/%srecover.",
+			path, purgeTime.Format(time.RFC3339), path))
+		return resp, nil
+	}
+
 	// Attempt unmount
 	if err := b.Core.unmount(ctx, path); err != nil {
 		b.Backend.Logger().Error("unmount failed", "path", path, "error", err)
@@ -1852,6 +1968,28 @@ func (b *SystemBackend) handleUnmount(ctx context.Context, req *logical.Request,
 	return nil, nil
 }
 
+// handleMountRecover is used to restore a mount that is pending deletion
+func (b *SystemBackend) handleMountRecover(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	path := data.Get("path").(string)
+	path = sanitizePath(path)
+
+	repState := b.Core.ReplicationState()
+	entry := b.Core.router.MatchingMountEntry(ctx, path)
+
+	// If we are a performance secondary cluster we should forward the request
+	// to the primary.
+	if entry != nil && !entry.Local && repState.HasState(consts.ReplicationPerformanceSecondary) {
+		return nil, logical.ErrReadOnly
+	}
+
+	if err := b.Core.recoverMount(ctx, path); err != nil {
+		b.Backend.Logger().Error("mount recovery failed", "path", path, "error", err)
+		return handleError(err)
+	}
+
+	return nil, nil
+}
+
 func validateMountPath(p string) error {
 	hasSuffix := strings.HasSuffix(p, "/")
 	s := path.Clean(p)
@@ -2071,6 +2209,32 @@ func (b *SystemBackend) handleRemountStatusCheck(ctx context.Context, req *logic
 	return resp, nil
 }
 
+// handleRollback is used to trigger an immediate rollback of a mount
+func (b *SystemBackend) handleRollback(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	path := data.Get("path").(string)
+	if path == "" {
+		return logical.ErrorResponse("path must be specified"), logical.ErrInvalidRequest
+	}
+	path = sanitizePath(path)
+
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if b.Core.router.MatchingMount(ctx, path) != ns.Path+path || b.Core.router.MatchingBackend(ctx, path) == nil {
+		return logical.ErrorResponse("no mount found at %q", path), logical.ErrInvalidRequest
+	}
+
+	if b.Core.rollback == nil {
+		return nil, errors.New("rollback manager is not running")
+	}
+	if err := b.Core.rollback.Rollback(ctx, path); err != nil {
+		return nil, fmt.Errorf("rollback of %q failed: %w", path, err)
+	}
+
+	return nil, nil
+}
+
 // handleMountTuneRead is used to get config settings on a backend
 func (b *SystemBackend) handleMountTuneRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
 	path := data.Get("path").(string)
@@ -2133,6 +2297,14 @@ func (b *SystemBackend) handleTuneReadCommon(ctx context.Context, path string) (
 		resp.Data["listing_visibility"] = mountEntry.Config.ListingVisibility
 	}
 
+	if mountEntry.Config.RollbackInterval > 0 {
+		resp.Data["rollback_interval"] = int64(mountEntry.Config.RollbackInterval.Seconds())
+	}
+
+	if mountEntry.Config.DeletionGracePeriod > 0 {
+		resp.Data["deletion_grace_period"] = int64(mountEntry.Config.DeletionGracePeriod.Seconds())
+	}
+
 	if rawVal, ok := mountEntry.synthesizedConfigCache.Load("passthrough_request_headers"); ok {
 		resp.Data["passthrough_request_headers"] = rawVal.([]string)
 	}
@@ -2612,6 +2784,56 @@ func (b *SystemBackend) handleTuneWriteCommon(ctx context.Context, path string,
 		}
 	}
 
+	if rawVal, ok := data.GetOk("rollback_interval"); ok {
+		rollbackInterval := time.Duration(rawVal.(int)) * time.Second
+		oldVal := mountEntry.Config.RollbackInterval
+		mountEntry.Config.RollbackInterval = rollbackInterval
+
+		// Update the mount table
+		var err error
+		switch {
+		case strings.HasPrefix(path, "auth/"):
+			err = b.Core.persistAuth(ctx, b.Core.auth, &mountEntry.Local)
+		default:
+			err = b.Core.persistMounts(ctx, b.Core.mounts, &mountEntry.Local)
+		}
+		if err != nil {
+			mountEntry.Config.RollbackInterval = oldVal
+			return handleError(err)
+		}
+
+		if b.Core.logger.IsInfo() {
+			b.Core.logger.Info("mount tuning of rollback_interval successful", "path", path, "rollback_interval", rollbackInterval)
+		}
+	}
+
+	// The auth tune path doesn't have this field, since only secrets engines
+	// can be pending deletion.
+	if _, ok := data.Schema["deletion_grace_period"]; ok {
+		if rawVal, ok := data.GetOk("deletion_grace_period"); ok {
+			if strings.HasPrefix(path, "auth/") {
+				return logical.ErrorResponse("deletion_grace_period is only supported for secrets engines"), logical.ErrInvalidRequest
+			}
+
+			gracePeriod := time.Duration(rawVal.(int)) * time.Second
+			if gracePeriod < 0 {
+				return logical.ErrorResponse("deletion_grace_period cannot be negative"), logical.ErrInvalidRequest
+			}
+			oldVal := mountEntry.Config.DeletionGracePeriod
+			mountEntry.Config.DeletionGracePeriod = gracePeriod
+
+			// Update the mount table
+			if err := b.Core.persistMounts(ctx, b.Core.mounts, &mountEntry.Local); err != nil {
+				mountEntry.Config.DeletionGracePeriod = oldVal
+				return handleError(err)
+			}
+
+			if b.Core.logger.IsInfo() {
+				b.Core.logger.Info("mount tuning of deletion_grace_period successful", "path", path, "deletion_grace_period", gracePeriod)
+			}
+		}
+	}
+
 	if rawVal, ok := data.GetOk("token_type"); ok {
 		if !strings.HasPrefix(path, "auth/") {
 			return logical.ErrorResponse(fmt.Sprintf("'token_type' can only be modified on auth mounts")), logical.ErrInvalidRequest
@@ -3270,6 +3492,23 @@ func (b *SystemBackend) handleEnableAuth(ctx context.Context, req *logical.Reque
 	}
 	config.ListingVisibility = apiConfig.ListingVisibility
 
+	if apiConfig.RollbackInterval != "" {
+		rollbackInterval, err := parseutil.ParseDurationSecond(apiConfig.RollbackInterval)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprintf(
+					"unable to parse rollback interval of %s: %s", apiConfig.RollbackInterval, err)),
+				logical.ErrInvalidRequest
+		}
+		if rollbackInterval < 0 {
+			return logical.ErrorResponse("rollback_interval cannot be negative"), logical.ErrInvalidRequest
+		}
+		config.RollbackInterval = rollbackInterval
+	}
+
+	if apiConfig.DeletionGracePeriod != "" {
+		return logical.ErrorResponse("deletion_grace_period is only supported for secrets engines"), logical.ErrInvalidRequest
+	}
+
 	if len(apiConfig.AuditNonHMACRequestKeys) > 0 {
 		config.AuditNonHMACRequestKeys = apiConfig.AuditNonHMACRequestKeys
 	}
@@ -3646,6 +3885,80 @@ func (b *SystemBackend) handlePoliciesDelete(policyType PolicyType) framework.Op
 	}
 }
 
+// handlePoliciesACLValidate evaluates an ACL policy against a sample request
+// without storing it. The policy body is taken from the request if given,
+// otherwise the stored policy with the given name is used.
+func (b *SystemBackend) handlePoliciesACLValidate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(data.Get("name").(string))
+	if name == "" {
+		return logical.ErrorResponse("policy name must be provided in the URL"), nil
+	}
+
+	raw := data.Get("policy").(string)
+	if raw == "" {
+		stored, err := b.Core.policyStore.GetPolicy(ctx, name, PolicyTypeACL)
+		if err != nil {
+			return handleError(err)
+		}
+		if stored == nil {
+			return logical.ErrorResponse("policy %q does not exist and no 'policy' was supplied", name), nil
+		}
+		raw = stored.Raw
+	}
+	if polBytes, err := base64.StdEncoding.DecodeString(raw); err == nil {
+		raw = string(polBytes)
+	}
+
+	reqPath := strings.TrimPrefix(data.Get("path").(string), "/")
+	if reqPath == "" {
+		return logical.ErrorResponse("'path' parameter not supplied or empty"), nil
+	}
+
+	op := logical.Operation(strings.ToLower(data.Get("operation").(string)))
+	switch op {
+	case logical.ReadOperation, logical.ListOperation, logical.CreateOperation,
+		logical.UpdateOperation, logical.PatchOperation, logical.DeleteOperation:
+	default:
+		return logical.ErrorResponse("unsupported operation %q", op), nil
+	}
+
+	policy, err := ParseACLPolicy(ns, raw)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+	policy.Name = name
+
+	acl, err := NewACL(ctx, []*Policy{policy})
+	if err != nil {
+		return handleError(err)
+	}
+
+	sampleReq := &logical.Request{
+		Path:      reqPath,
+		Operation: op,
+		Data:      data.Get("data").(map[string]interface{}),
+	}
+	results := acl.AllowOperation(ctx, sampleReq, false)
+	_, rule := acl.matchingRule(strings.TrimLeft(ns.Path+reqPath, "/"), op)
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"allowed":      results.Allowed,
+			"capabilities": acl.Capabilities(ctx, reqPath),
+			"rule":         strings.TrimPrefix(rule, ns.Path),
+		},
+	}
+	if policy.Templated {
+		resp.AddWarning("policy is templated; templated paths are only evaluated against real tokens and were ignored")
+	}
+	return resp, nil
+}
+
 type passwordPolicyConfig struct {
 	HCLPolicy string `json:"policy"`
 }
@@ -4589,6 +4902,7 @@ func (b *SystemBackend) handleWrappingLookup(ctx context.Context, req *logical.R
 	creationTTLRaw := cubbyResp.Data["creation_ttl"]
 	creationTime := cubbyResp.Data["creation_time"]
 	creationPath := cubbyResp.Data["creation_path"]
+	creatorAccessor := cubbyResp.Data["creator_accessor"]
 
 	resp := &logical.Response{
 		Data: map[string]interface{}{},
@@ -4607,6 +4921,10 @@ func (b *SystemBackend) handleWrappingLookup(ctx context.Context, req *logical.R
 	if creationPath != nil {
 		resp.Data["creation_path"] = cubbyResp.Data["creation_path"]
 	}
+	// Wrapping tokens created by older versions did not record their creator
+	if creatorAccessor != nil {
+		resp.Data["creator_accessor"] = creatorAccessor
+	}
 
 	return resp, nil
 }
@@ -4683,6 +5001,7 @@ func (b *SystemBackend) handleWrappingRewrap(ctx context.Context, req *logical.R
 		return nil, fmt.Errorf("creation_path value in wrapping information was nil")
 	}
 	creationPath := creationPathRaw.(string)
+	creatorAccessor := cubbyResp.Data["creator_accessor"]
 
 	// Fetch the original response and return it as the data for the new response
 	cubbyReq = &logical.Request{
@@ -4712,7 +5031,7 @@ func (b *SystemBackend) handleWrappingRewrap(ctx context.Context, req *logical.R
 
 	// Return response in "response"; wrapping code will detect the rewrap and
 	// slot in instead of nesting
-	return &logical.Response{
+	resp := &logical.Response{
 		Data: map[string]interface{}{
 			"response": response,
 		},
@@ -4720,7 +5039,11 @@ func (b *SystemBackend) handleWrappingRewrap(ctx context.Context, req *logical.R
 			TTL:          time.Duration(creationTTL),
 			CreationPath: creationPath,
 		},
-	}, nil
+	}
+	if creatorAccessor != nil {
+		resp.Data["creator_accessor"] = creatorAccessor
+	}
+	return resp, nil
 }
 
 func (b *SystemBackend) pathHashWrite(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
@@ -6591,6 +6914,16 @@ or delete a policy.
 		"",
 	},
 
+	"policy-validate": {
+		"Evaluate an ACL policy against a sample request without saving it.",
+		`
+This path checks that an ACL policy parses and reports whether it would allow
+the given operation on the given path, which capabilities it grants there, and
+which of its path rules matched. If no policy body is supplied, the stored
+policy with the given name is evaluated instead. Nothing is written.
+		`,
+	},
+
 	"policy-rules": {
 		`The rules of the policy.`,
 		"",
@@ -6845,6 +7178,19 @@ Must already be present on the machine.`,
 		`The Vault plugin runtime to use when running the plugin.`,
 		"",
 	},
+	"plugin-catalog_download_url": {
+		`The HTTPS URL to download the plugin binary from. The binary is
+verified against sha256 and signature before it is written to the plugin
+directory as <name>-<version>, or as the plugin name if no version is given.
+Cannot be used with command.`,
+		"",
+	},
+	"plugin-catalog_signature": {
+		`The base64-encoded detached PGP signature of the plugin binary. It
+must be made by one of the keys in the plugin_download_pgp_keys server
+configuration. Required with download_url.`,
+		"",
+	},
 	"plugin-catalog-pins": {
 		"Configures pinned plugin versions from the plugin catalog",
 		`
@@ -6920,6 +7266,39 @@ This path responds to the following HTTP methods.
 		"The name of the key used to sign plugin identity tokens. Defaults to the default key.",
 		"",
 	},
+	"rollback_interval": {
+		"The minimum time between periodic rollbacks of the mount. Defaults to the server's rollback period.",
+		"",
+	},
+	"deletion_grace_period": {
+		"How long a disabled secrets engine is kept, pending deletion, before its data is removed. Defaults to the server's mount_deletion_grace_period.",
+		"",
+	},
+	"mount_purge": {
+		"Remove the secrets engine and revoke its leases immediately when disabling it, instead of keeping it pending deletion.",
+		"",
+	},
+	"mount_recover": {
+		"Restore a secrets engine that is pending deletion.",
+		`
+This path restores a secrets engine that was disabled while it had a deletion
+grace period, as long as the grace period hasn't passed yet. The mount serves
+requests again, with its data and leases intact.
+		`,
+	},
+	"rollback": {
+		"Trigger an immediate rollback of a mount.",
+		`
+This path invokes a rollback operation on the given secrets engine or auth
+method mount, so that partially completed operations are cleaned up without
+waiting for the next periodic rollback. If a rollback of the mount is already
+in progress, the request waits for it to complete.
+		`,
+	},
+	"rollback_path": {
+		"The path of the mount to roll back. Auth method mounts are prefixed by 'auth/'.",
+		"",
+	},
 	"leases": {
 		`View or list lease metadata.`,
 		`
@@ -1891,6 +1891,14 @@ func (b *SystemBackend) pluginsCatalogCRUDPath() *framework.Path {
 				Type:        framework.TypeString,
 				Description: strings.TrimSpace(sysHelp["plugin-catalog_version"][0]),
 			},
+			"download_url": {
+				Type:        framework.TypeString,
+				Description: strings.TrimSpace(sysHelp["plugin-catalog_download_url"][0]),
+			},
+			"signature": {
+				Type:        framework.TypeString,
+				Description: strings.TrimSpace(sysHelp["plugin-catalog_signature"][0]),
+			},
 		},
 
 		Operations: map[logical.Operation]framework.OperationHandler{
@@ -3025,6 +3033,10 @@ func (b *SystemBackend) capabilitiesPaths() []*framework.Path {
 					Type:        framework.TypeCommaStringSlice,
 					Description: "Paths on which capabilities are being queried.",
 				},
+				"explain": {
+					Type:        framework.TypeBool,
+					Description: "If true, also return the policies and policy rules that grant or deny capabilities on each path.",
+				},
 			},
 
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -3066,6 +3078,10 @@ func (b *SystemBackend) capabilitiesPaths() []*framework.Path {
 					Type:        framework.TypeCommaStringSlice,
 					Description: "Paths on which capabilities are being queried.",
 				},
+				"explain": {
+					Type:        framework.TypeBool,
+					Description: "If true, also return the policies and policy rules that grant or deny capabilities on each path.",
+				},
 			},
 
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -3107,6 +3123,10 @@ func (b *SystemBackend) capabilitiesPaths() []*framework.Path {
 					Type:        framework.TypeCommaStringSlice,
 					Description: "Paths on which capabilities are being queried.",
 				},
+				"explain": {
+					Type:        framework.TypeBool,
+					Description: "If true, also return the policies and policy rules that grant or deny capabilities on each path.",
+				},
 			},
 
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -3510,6 +3530,39 @@ func (b *SystemBackend) leasePaths() []*framework.Path {
 	}
 }
 
+func (b *SystemBackend) rollbackPaths() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "rollback/" + framework.MatchAllRegex("path"),
+
+			DisplayAttrs: &framework.DisplayAttributes{
+				OperationVerb: "rollback",
+			},
+
+			Fields: map[string]*framework.FieldSchema{
+				"path": {
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["rollback_path"][0]),
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handleRollback,
+					Responses: map[int][]framework.Response{
+						http.StatusNoContent: {{
+							Description: "OK",
+						}},
+					},
+					Summary: "Trigger an immediate rollback of a mount.",
+				},
+			},
+			HelpSynopsis:    strings.TrimSpace(sysHelp["rollback"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["rollback"][1]),
+		},
+	}
+}
+
 func (b *SystemBackend) remountPaths() []*framework.Path {
 	return []*framework.Path{
 		{
@@ -3826,6 +3879,10 @@ func (b *SystemBackend) authPaths() []*framework.Path {
 					Description: strings.TrimSpace(sysHelp["identity_token_key"][0]),
 					Required:    false,
 				},
+				"rollback_interval": {
+					Type:        framework.TypeDurationSecond,
+					Description: strings.TrimSpace(sysHelp["rollback_interval"][0]),
+				},
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.ReadOperation: &framework.PathOperation{
@@ -3916,6 +3973,10 @@ func (b *SystemBackend) authPaths() []*framework.Path {
 									Type:     framework.TypeString,
 									Required: false,
 								},
+								"rollback_interval": {
+									Type:     framework.TypeInt64,
+									Required: false,
+								},
 							},
 						}},
 					},
@@ -4257,6 +4318,94 @@ func (b *SystemBackend) policyPaths() []*framework.Path {
 			HelpDescription: strings.TrimSpace(sysHelp["policy-list"][1]),
 		},
 
+		{
+			Pattern: "policies/acl-validate/(?P<name>.+)",
+
+			DisplayAttrs: &framework.DisplayAttributes{
+				OperationPrefix: "policies",
+				OperationVerb:   "validate",
+				OperationSuffix: "acl-policy",
+			},
+
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["policy-name"][0]),
+				},
+				"policy": {
+					Type:        framework.TypeString,
+					Description: "The candidate policy to evaluate. Defaults to the stored policy with the given name.",
+				},
+				"path": {
+					Type:        framework.TypeString,
+					Description: "The request path to evaluate the policy against.",
+					Query:       true,
+				},
+				"operation": {
+					Type:        framework.TypeString,
+					Default:     "read",
+					Description: "The request operation to evaluate the policy against.",
+					Query:       true,
+				},
+				"data": {
+					Type:        framework.TypeMap,
+					Description: "Request parameters to check against the policy's parameter constraints.",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{
+					Callback: b.handlePoliciesACLValidate,
+					Responses: map[int][]framework.Response{
+						http.StatusOK: {{
+							Description: "OK",
+							Fields: map[string]*framework.FieldSchema{
+								"allowed": {
+									Type:     framework.TypeBool,
+									Required: true,
+								},
+								"capabilities": {
+									Type:     framework.TypeStringSlice,
+									Required: true,
+								},
+								"rule": {
+									Type:     framework.TypeString,
+									Required: true,
+								},
+							},
+						}},
+					},
+					Summary: "Evaluate a stored ACL policy against a sample request.",
+				},
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handlePoliciesACLValidate,
+					Responses: map[int][]framework.Response{
+						http.StatusOK: {{
+							Description: "OK",
+							Fields: map[string]*framework.FieldSchema{
+								"allowed": {
+									Type:     framework.TypeBool,
+									Required: true,
+								},
+								"capabilities": {
+									Type:     framework.TypeStringSlice,
+									Required: true,
+								},
+								"rule": {
+									Type:     framework.TypeString,
+									Required: true,
+								},
+							},
+						}},
+					},
+					Summary: "Evaluate an ACL policy against a sample request without saving it.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["policy-validate"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["policy-validate"][1]),
+		},
+
 		{
 			Pattern: "policies/acl/(?P<name>.+)",
 
@@ -4543,6 +4692,10 @@ func (b *SystemBackend) wrappingPaths() []*framework.Path {
 									Type:     framework.TypeString,
 									Required: false,
 								},
+								"creator_accessor": {
+									Type:     framework.TypeString,
+									Required: false,
+								},
 							},
 						}},
 					},
@@ -4570,6 +4723,10 @@ func (b *SystemBackend) wrappingPaths() []*framework.Path {
 									Type:     framework.TypeString,
 									Required: false,
 								},
+								"creator_accessor": {
+									Type:     framework.TypeString,
+									Required: false,
+								},
 							},
 						}},
 					},
@@ -4686,6 +4843,14 @@ func (b *SystemBackend) mountPaths() []*framework.Path {
 					Type:        framework.TypeString,
 					Description: strings.TrimSpace(sysHelp["identity_token_key"][0]),
 				},
+				"rollback_interval": {
+					Type:        framework.TypeDurationSecond,
+					Description: strings.TrimSpace(sysHelp["rollback_interval"][0]),
+				},
+				"deletion_grace_period": {
+					Type:        framework.TypeDurationSecond,
+					Description: strings.TrimSpace(sysHelp["deletion_grace_period"][0]),
+				},
 			},
 
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -4788,6 +4953,14 @@ func (b *SystemBackend) mountPaths() []*framework.Path {
 									Type:     framework.TypeString,
 									Required: false,
 								},
+								"rollback_interval": {
+									Type:     framework.TypeInt64,
+									Required: false,
+								},
+								"deletion_grace_period": {
+									Type:     framework.TypeInt64,
+									Required: false,
+								},
 							},
 						}},
 					},
@@ -4810,6 +4983,40 @@ func (b *SystemBackend) mountPaths() []*framework.Path {
 			HelpDescription: strings.TrimSpace(sysHelp["mount_tune"][1]),
 		},
 
+		{
+			Pattern: "mounts/(?P<path>.+?)/recover$",
+
+			DisplayAttrs: &framework.DisplayAttributes{
+				OperationPrefix: "mounts",
+			},
+
+			Fields: map[string]*framework.FieldSchema{
+				"path": {
+					Type:        framework.TypeString,
+					Description: strings.TrimSpace(sysHelp["mount_path"][0]),
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: b.handleMountRecover,
+					DisplayAttrs: &framework.DisplayAttributes{
+						OperationVerb:   "recover",
+						OperationSuffix: "secrets-engine",
+					},
+					Responses: map[int][]framework.Response{
+						http.StatusNoContent: {{
+							Description: "OK",
+						}},
+					},
+					Summary: "Restore a secrets engine that is pending deletion.",
+				},
+			},
+
+			HelpSynopsis:    strings.TrimSpace(sysHelp["mount_recover"][0]),
+			HelpDescription: strings.TrimSpace(sysHelp["mount_recover"][1]),
+		},
+
 		{
 			Pattern: "mounts/(?P<path>.+?)",
 
@@ -4861,6 +5068,11 @@ func (b *SystemBackend) mountPaths() []*framework.Path {
 					Type:        framework.TypeString,
 					Description: strings.TrimSpace(sysHelp["plugin-catalog_version"][0]),
 				},
+				"purge": {
+					Type:        framework.TypeBool,
+					Default:     false,
+					Description: strings.TrimSpace(sysHelp["mount_purge"][0]),
+				},
 			},
 
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -2523,6 +2523,207 @@ func TestSystemBackend_tuneAuth(t *testing.T) {
 	}
 }
 
+// TestSystemBackend_tune_rollbackInterval verifies that the rollback interval
+// of a mount can be tuned and read back.
+func TestSystemBackend_tune_rollbackInterval(t *testing.T) {
+	ctx := namespace.RootContext(nil)
+	core, b, _ := testCoreSystemBackend(t)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "mounts/secret/tune")
+	req.Data["rollback_interval"] = "-1s"
+	resp, err := b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	require.True(t, resp.IsError())
+
+	req.Data["rollback_interval"] = "1h"
+	resp, err = b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	req = logical.TestRequest(t, logical.ReadOperation, "mounts/secret/tune")
+	resp, err = b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	schema.ValidateResponse(
+		t,
+		schema.GetResponseSchema(t, core.systemBackend.Route(req.Path), req.Operation),
+		resp,
+		true,
+	)
+	require.Equal(t, int64(3600), resp.Data["rollback_interval"])
+
+	entry := core.router.MatchingMountEntry(ctx, "secret/")
+	require.NotNil(t, entry)
+	require.Equal(t, time.Hour, entry.Config.RollbackInterval)
+}
+
+// TestSystemBackend_unmount_deletionGracePeriod verifies that disabling a mount
+// with a deletion grace period keeps its data until the grace period has
+// passed, and that it can be recovered until then.
+func TestSystemBackend_unmount_deletionGracePeriod(t *testing.T) {
+	ctx := namespace.RootContext(nil)
+	core, b, root := testCoreSystemBackend(t)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "mounts/foo")
+	req.Data["type"] = "kv"
+	req.Data["config"] = map[string]interface{}{
+		"deletion_grace_period": "1h",
+	}
+	resp, err := b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	secretReq := &logical.Request{
+		Operation:   logical.UpdateOperation,
+		Path:        "foo/bar",
+		ClientToken: root,
+		Data:        map[string]interface{}{"zip": "zap"},
+	}
+	resp, err = core.HandleRequest(ctx, secretReq)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	resp, err = b.HandleRequest(ctx, logical.TestRequest(t, logical.DeleteOperation, "mounts/foo"))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Len(t, resp.Warnings, 1)
+
+	// The mount no longer serves requests, but is still listed
+	readReq := &logical.Request{
+		Operation:   logical.ReadOperation,
+		Path:        "foo/bar",
+		ClientToken: root,
+	}
+	_, err = core.HandleRequest(ctx, readReq)
+	require.ErrorIs(t, err, logical.ErrUnsupportedPath)
+
+	resp, err = b.HandleRequest(ctx, logical.TestRequest(t, logical.ReadOperation, "mounts/foo"))
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Data["purge_time"])
+
+	// The path can't be reused while the mount is pending deletion
+	req = logical.TestRequest(t, logical.UpdateOperation, "mounts/foo")
+	req.Data["type"] = "kv"
+	resp, err = b.HandleRequest(ctx, req)
+	require.Error(t, err)
+
+	resp, err = b.HandleRequest(ctx, logical.TestRequest(t, logical.UpdateOperation, "mounts/foo/recover"))
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	resp, err = core.HandleRequest(ctx, readReq)
+	require.NoError(t, err)
+	require.Equal(t, "zap", resp.Data["zip"])
+
+	// Recovering a mount that isn't pending deletion fails
+	resp, err = b.HandleRequest(ctx, logical.TestRequest(t, logical.UpdateOperation, "mounts/foo/recover"))
+	require.Error(t, err)
+	require.True(t, resp.IsError())
+
+	// Once the grace period has passed the mount is removed
+	resp, err = b.HandleRequest(ctx, logical.TestRequest(t, logical.DeleteOperation, "mounts/foo"))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	core.purgeExpiredMounts(ctx)
+	require.NotNil(t, core.router.MatchingMountEntry(ctx, "foo/"))
+
+	core.router.MatchingMountEntry(ctx, "foo/").PurgeTime = time.Now().Add(-time.Minute).Unix()
+	core.purgeExpiredMounts(ctx)
+	require.Nil(t, core.router.MatchingMountEntry(ctx, "foo/"))
+}
+
+// TestSystemBackend_unmount_pendingDeletion verifies that disabling a mount
+// that is already pending deletion removes it right away.
+func TestSystemBackend_unmount_pendingDeletion(t *testing.T) {
+	ctx := namespace.RootContext(nil)
+	core, b, _ := testCoreSystemBackend(t)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "mounts/foo")
+	req.Data["type"] = "kv"
+	resp, err := b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "mounts/foo/tune")
+	req.Data["deletion_grace_period"] = "1h"
+	resp, err = b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	resp, err = b.HandleRequest(ctx, logical.TestRequest(t, logical.DeleteOperation, "mounts/foo"))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.NotNil(t, core.router.MatchingMountEntry(ctx, "foo/"))
+
+	// Remounting is refused while the mount is pending deletion
+	err = core.remountSecretsEngineCurrentNamespace(ctx, "foo/", "bar/", true)
+	require.Error(t, err)
+
+	resp, err = b.HandleRequest(ctx, logical.TestRequest(t, logical.DeleteOperation, "mounts/foo"))
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Nil(t, core.router.MatchingMountEntry(ctx, "foo/"))
+}
+
+// TestSystemBackend_unmount_defaultGracePeriod verifies that mounts without
+// their own deletion grace period use the server's, and that purging removes
+// a mount right away.
+func TestSystemBackend_unmount_defaultGracePeriod(t *testing.T) {
+	ctx := namespace.RootContext(nil)
+	core, b, _ := testCoreSystemBackend(t)
+	core.mountDeletionGracePeriod = time.Hour
+
+	for _, path := range []string{"foo", "bar"} {
+		req := logical.TestRequest(t, logical.UpdateOperation, "mounts/"+path)
+		req.Data["type"] = "kv"
+		resp, err := b.HandleRequest(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, resp)
+	}
+
+	resp, err := b.HandleRequest(ctx, logical.TestRequest(t, logical.DeleteOperation, "mounts/foo"))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Len(t, resp.Warnings, 1)
+	entry := core.router.MatchingMountEntry(ctx, "foo/")
+	require.NotNil(t, entry)
+	require.Equal(t, mountStatePendingDeletion, entry.MountState)
+
+	req := logical.TestRequest(t, logical.DeleteOperation, "mounts/bar")
+	req.Data["purge"] = true
+	resp, err = b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Nil(t, core.router.MatchingMountEntry(ctx, "bar/"))
+}
+
+// TestSystemBackend_rollback verifies that a rollback can be triggered for an
+// existing mount, and that unknown paths are rejected.
+func TestSystemBackend_rollback(t *testing.T) {
+	ctx := namespace.RootContext(nil)
+	_, b, _ := testCoreSystemBackend(t)
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "rollback/secret")
+	resp, err := b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "rollback/auth/token")
+	resp, err = b.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "rollback/secret/foo")
+	resp, err = b.HandleRequest(ctx, req)
+	require.ErrorIs(t, err, logical.ErrInvalidRequest)
+	require.True(t, resp.IsError())
+
+	req = logical.TestRequest(t, logical.UpdateOperation, "rollback/nonexistent")
+	resp, err = b.HandleRequest(ctx, req)
+	require.ErrorIs(t, err, logical.ErrInvalidRequest)
+	require.True(t, resp.IsError())
+}
+
 // TestSystemBackend_tune_updatePreV1MountEntryType tests once Vault is migrated post-v1.0.0,
 // the secret/auth mount was enabled in Vault pre-v1.0.0 has its MountEntry.Type updated
 // to the plugin name when tuned with plugin_version
@@ -2808,6 +3009,146 @@ func TestSystemBackend_policyCRUD(t *testing.T) {
 	}
 }
 
+func TestSystemBackend_policyValidate(t *testing.T) {
+	b := testSystemBackend(t)
+	ctx := namespace.RootContext(nil)
+
+	candidate := `
+path "secret/+/config" {
+	capabilities = ["read", "update"]
+	allowed_parameters = {
+		"ttl" = ["1h"]
+	}
+}
+`
+	tCases := map[string]struct {
+		operation logical.Operation
+		path      string
+		data      map[string]interface{}
+		allowed   bool
+		caps      []string
+		rule      string
+	}{
+		"allowed read": {
+			operation: logical.ReadOperation,
+			path:      "secret/app/config",
+			allowed:   true,
+			caps:      []string{"read", "update"},
+			rule:      "secret/+/config",
+		},
+		"disallowed parameter value": {
+			operation: logical.UpdateOperation,
+			path:      "secret/app/config",
+			data:      map[string]interface{}{"ttl": "2h"},
+			allowed:   false,
+			caps:      []string{"read", "update"},
+			rule:      "secret/+/config",
+		},
+		"no matching rule": {
+			operation: logical.DeleteOperation,
+			path:      "secret/app/other",
+			allowed:   false,
+			caps:      []string{"deny"},
+			rule:      "",
+		},
+	}
+	for name, tc := range tCases {
+		t.Run(name, func(t *testing.T) {
+			req := logical.TestRequest(t, logical.UpdateOperation, "policies/acl-validate/candidate")
+			req.Data = map[string]interface{}{
+				"policy":    candidate,
+				"path":      tc.path,
+				"operation": string(tc.operation),
+				"data":      tc.data,
+			}
+			resp, err := b.HandleRequest(ctx, req)
+			if err != nil || resp.IsError() {
+				t.Fatalf("err: %v %#v", err, resp)
+			}
+			schema.ValidateResponse(
+				t,
+				schema.GetResponseSchema(t, b.(*SystemBackend).Route(req.Path), req.Operation),
+				resp,
+				true,
+			)
+			exp := map[string]interface{}{
+				"allowed":      tc.allowed,
+				"capabilities": tc.caps,
+				"rule":         tc.rule,
+			}
+			if !reflect.DeepEqual(resp.Data, exp) {
+				t.Fatalf("got: %#v expect: %#v", resp.Data, exp)
+			}
+		})
+	}
+
+	// The candidate must not have been stored
+	req := logical.TestRequest(t, logical.ReadOperation, "policies/acl/candidate")
+	resp, err := b.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp != nil {
+		t.Fatalf("validated policy should not be stored: %#v", resp)
+	}
+
+	// Without a body, the stored policy is evaluated
+	req = logical.TestRequest(t, logical.ReadOperation, "policies/acl-validate/default")
+	req.Data["path"] = "auth/token/lookup-self"
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil || resp.IsError() {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+	if resp.Data["allowed"] != true || resp.Data["rule"] != "auth/token/lookup-self" {
+		t.Fatalf("bad: %#v", resp.Data)
+	}
+
+	// Invalid policies are reported as errors
+	req = logical.TestRequest(t, logical.UpdateOperation, "policies/acl-validate/candidate")
+	req.Data["policy"] = `path "secret/*" { capabilities = ["bogus"] }`
+	req.Data["path"] = "secret/foo"
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil || !resp.IsError() {
+		t.Fatalf("expected a parse error, got: %v %#v", err, resp)
+	}
+}
+
+// TestSystemBackend_policyNamedValidate tests that a policy whose name ends in
+// "/validate" is handled like any other policy, not as a dry run.
+func TestSystemBackend_policyNamedValidate(t *testing.T) {
+	b := testSystemBackend(t)
+	ctx := namespace.RootContext(nil)
+	rules := `path "secret/*" { capabilities = ["read"] }`
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "policies/acl/team/validate")
+	req.Data["policy"] = rules
+	resp, err := b.HandleRequest(ctx, req)
+	if err != nil || resp != nil {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "policies/acl/team/validate")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp == nil || resp.Data["name"] != "team/validate" || resp.Data["policy"] != rules {
+		t.Fatalf("bad: %#v", resp)
+	}
+
+	req = logical.TestRequest(t, logical.DeleteOperation, "policies/acl/team/validate")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil || resp != nil {
+		t.Fatalf("err: %v %#v", err, resp)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "policies/acl/team/validate")
+	resp, err = b.HandleRequest(ctx, req)
+	if err != nil || resp != nil {
+		t.Fatalf("expected the policy to be deleted, got: %v %#v", err, resp)
+	}
+}
+
 func TestSystemBackend_enableAudit(t *testing.T) {
 	_, b, _ := testCoreSystemBackend(t)
 
@@ -3217,6 +3558,52 @@ func TestSystemBackend_rawReadWrite(t *testing.T) {
 	// simply parse this out directly via GetPolicy, so the test now ends here.
 }
 
+func TestSystemBackend_rawRead_Subkeys(t *testing.T) {
+	_, b, _ := testCoreSystemBackendRaw(t)
+
+	req := logical.TestRequest(t, logical.CreateOperation, "raw/test/entry")
+	req.Data["value"] = `{"foo":"bar","nested":{"baz":1,"deeper":{"qux":true}}}`
+	req.Data["compression_type"] = compressutil.CompressionTypeGzip
+	resp, err := b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp != nil {
+		t.Fatalf("bad: %v", resp)
+	}
+
+	req = logical.TestRequest(t, logical.ReadOperation, "raw/test/entry")
+	req.Data["subkeys"] = true
+	resp, err = b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	expected := map[string]interface{}{
+		"foo": nil,
+		"nested": map[string]interface{}{
+			"baz": nil,
+			"deeper": map[string]interface{}{
+				"qux": nil,
+			},
+		},
+	}
+	if diff := deep.Equal(resp.Data["subkeys"], expected); diff != nil {
+		t.Fatal(diff)
+	}
+
+	// Values that aren't JSON objects have no subkeys
+	req = logical.TestRequest(t, logical.CreateOperation, "raw/test/plain")
+	req.Data["value"] = "not json"
+	if _, err := b.HandleRequest(namespace.RootContext(nil), req); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	req = logical.TestRequest(t, logical.ReadOperation, "raw/test/plain")
+	req.Data["subkeys"] = true
+	if _, err := b.HandleRequest(namespace.RootContext(nil), req); err != logical.ErrInvalidRequest {
+		t.Fatalf("expected invalid request, got: %v", err)
+	}
+}
+
 func TestSystemBackend_rawWrite_ExistanceCheck(t *testing.T) {
 	b := testSystemBackendRaw(t)
 	req := logical.TestRequest(t, logical.CreateOperation, "raw/core/mounts")
@@ -4213,6 +4600,31 @@ func TestSystemBackend_PluginCatalog_CannotRegisterBuiltinPlugins(t *testing.T)
 	}
 }
 
+// TestSystemBackend_PluginCatalog_DownloadWithCommand tests that a plugin
+// registered with a download URL can't choose the file it's written to.
+func TestSystemBackend_PluginCatalog_DownloadWithCommand(t *testing.T) {
+	sym, err := filepath.EvalSymlinks(os.TempDir())
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	c, _, _ := TestCoreUnsealedWithConfig(t, &CoreConfig{
+		PluginDirectory: sym,
+	})
+	b := c.systemBackend
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "plugins/catalog/database/test-plugin")
+	req.Data["sha256"] = hex.EncodeToString([]byte{'1'})
+	req.Data["command"] = "foo"
+	req.Data["download_url"] = "https://example.com/test-plugin"
+	resp, err := b.HandleRequest(namespace.RootContext(nil), req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp == nil || !strings.Contains(resp.Error().Error(), "cannot be specified together with command") {
+		t.Fatalf("bad: %#v", resp)
+	}
+}
+
 func TestSystemBackend_ToolsHash(t *testing.T) {
 	b := testSystemBackend(t)
 	req := logical.TestRequest(t, logical.UpdateOperation, "tools/hash")
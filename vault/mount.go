@@ -312,7 +312,10 @@ func (t *MountTable) sortEntriesByPathDepth() *MountTable {
 	return t
 }
 
-const mountStateUnmounting = "unmounting"
+const (
+	mountStateUnmounting      = "unmounting"
+	mountStatePendingDeletion = "pending-deletion"
+)
 
 // MountEntry is used to represent a mount table entry
 type MountEntry struct {
@@ -329,7 +332,8 @@ type MountEntry struct {
 	SealWrap              bool              `json:"seal_wrap"`                         // Whether to wrap CSPs
 	ExternalEntropyAccess bool              `json:"external_entropy_access,omitempty"` // Whether to allow external entropy source access
 	Tainted               bool              `json:"tainted,omitempty"`                 // Set as a Write-Ahead flag for unmount/remount
-	MountState            string            `json:"mount_state,omitempty"`             // The current mount state.  The only non-empty mount state right now is "unmounting"
+	MountState            string            `json:"mount_state,omitempty"`             // The current mount state, either empty, "unmounting" or "pending-deletion"
+	PurgeTime             int64             `json:"purge_time,omitempty"`              // Unix time after which a mount pending deletion is removed
 	NamespaceID           string            `json:"namespace_id"`
 
 	// namespace contains the populated namespace
@@ -362,6 +366,8 @@ type MountConfig struct {
 	UserLockoutConfig         *UserLockoutConfig    `json:"user_lockout_config,omitempty" mapstructure:"user_lockout_config"`
 	DelegatedAuthAccessors    []string              `json:"delegated_auth_accessors,omitempty" mapstructure:"delegated_auth_accessors"`
 	IdentityTokenKey          string                `json:"identity_token_key,omitempty" mapstructure:"identity_token_key"`
+	RollbackInterval          time.Duration         `json:"rollback_interval,omitempty" structs:"rollback_interval" mapstructure:"rollback_interval"`
+	DeletionGracePeriod       time.Duration         `json:"deletion_grace_period,omitempty" structs:"deletion_grace_period" mapstructure:"deletion_grace_period"`
 
 	// PluginName is the name of the plugin registered in the catalog.
 	//
@@ -403,6 +409,8 @@ type APIMountConfig struct {
 	PluginVersion             string                `json:"plugin_version,omitempty" mapstructure:"plugin_version"`
 	DelegatedAuthAccessors    []string              `json:"delegated_auth_accessors,omitempty" mapstructure:"delegated_auth_accessors"`
 	IdentityTokenKey          string                `json:"identity_token_key,omitempty" mapstructure:"identity_token_key"`
+	RollbackInterval          string                `json:"rollback_interval,omitempty" mapstructure:"rollback_interval"`
+	DeletionGracePeriod       string                `json:"deletion_grace_period,omitempty" mapstructure:"deletion_grace_period"`
 
 	// PluginName is the name of the plugin registered in the catalog.
 	//
@@ -777,6 +785,7 @@ func (c *Core) mountInternal(ctx context.Context, entry *MountEntry, updateStora
 	if c.logger.IsInfo() {
 		c.logger.Info("successful mount", "namespace", entry.Namespace().Path, "path", entry.Path, "type", entry.Type, "version", entry.RunningVersion)
 	}
+	c.sendMountEvent(entry, eventTypeMountEnable)
 	return nil
 }
 
@@ -959,6 +968,7 @@ func (c *Core) unmountInternal(ctx context.Context, path string, updateStorage b
 	if c.logger.IsInfo() {
 		c.logger.Info("successfully unmounted", "path", path, "namespace", ns.Path)
 	}
+	c.sendMountEvent(entry, eventTypeMountDisable)
 
 	return nil
 }
@@ -1141,6 +1151,9 @@ func (c *Core) remountSecretsEngine(ctx context.Context, src, dst namespace.Moun
 	if srcMatch == nil {
 		return fmt.Errorf("no matching mount at %q", src.Namespace.Path+src.MountPath)
 	}
+	if srcMatch.MountState == mountStatePendingDeletion {
+		return fmt.Errorf("mount at %q is pending deletion", src.Namespace.Path+src.MountPath)
+	}
 
 	if match := c.router.MountConflict(ctx, dstRelativePath); match != "" {
 		return fmt.Errorf("path in use at %q", match)
@@ -1236,12 +1249,12 @@ func (c *Core) splitNamespaceAndMountFromPath(currNs, path string) namespace.Mou
 // loadMounts is invoked as part of postUnseal to load the mount table
 func (c *Core) loadMounts(ctx context.Context) error {
 	// Load the existing mount table
-	raw, err := c.barrier.Get(ctx, coreMountConfigPath)
+	raw, err := c.getMountTableEntry(ctx, coreMountConfigPath)
 	if err != nil {
 		c.logger.Error("failed to read mount table", "error", err)
 		return errLoadMountsFailed
 	}
-	rawLocal, err := c.barrier.Get(ctx, coreLocalMountConfigPath)
+	rawLocal, err := c.getMountTableEntry(ctx, coreLocalMountConfigPath)
 	if err != nil {
 		c.logger.Error("failed to read local mount table", "error", err)
 		return errLoadMountsFailed
@@ -1460,7 +1473,7 @@ func (c *Core) persistMounts(ctx context.Context, table *MountTable, local *bool
 		}
 
 		// Write to the physical backend
-		if err := c.barrier.Put(ctx, entry); err != nil {
+		if err := c.putMountTableEntry(ctx, entry); err != nil {
 			c.logger.Error("failed to persist mount table", "error", err)
 			return nil, err
 		}
@@ -1675,6 +1688,10 @@ func (c *Core) unloadMounts(ctx context.Context) error {
 	c.mounts = nil
 	c.router.reset()
 	c.systemBarrierView = nil
+
+	// Another active node may shard the tables before this one is active again
+	c.mountTablesUnsharded.Delete(coreMountConfigPath)
+	c.mountTablesUnsharded.Delete(coreLocalMountConfigPath)
 	return nil
 }
 
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// mountDeletionCheckInterval is how often mounts pending deletion are checked
+// for having passed their grace period. It's a var to allow for testing.
+var mountDeletionCheckInterval = time.Minute
+
+// mountDeletionGracePeriodFor returns how long the given mount is kept,
+// pending deletion, once it's disabled. Mounts without their own grace period
+// use the server's.
+func (c *Core) mountDeletionGracePeriodFor(entry *MountEntry) time.Duration {
+	if entry.Config.DeletionGracePeriod > 0 {
+		return entry.Config.DeletionGracePeriod
+	}
+	return c.mountDeletionGracePeriod
+}
+
+// markMountPendingDeletion taints the mount at the given path, so it no longer
+// serves requests, and records when its grace period ends. The mount's data
+// and leases are kept until then, so the mount can still be recovered.
+func (c *Core) markMountPendingDeletion(ctx context.Context, path string) (time.Time, error) {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Ensure we end the path in a slash
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	// Prevent protected paths from being unmounted
+	for _, p := range protectedMounts {
+		if strings.HasPrefix(path, p) {
+			return time.Time{}, fmt.Errorf("cannot unmount %q", path)
+		}
+	}
+
+	c.mountsLock.Lock()
+	defer c.mountsLock.Unlock()
+
+	entry, err := c.mounts.find(ctx, path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if entry == nil {
+		return time.Time{}, fmt.Errorf("no matching mount")
+	}
+	if entry.Tainted {
+		return time.Time{}, fmt.Errorf("mount %q is already being unmounted or remounted", path)
+	}
+
+	purgeTime := time.Now().Add(c.mountDeletionGracePeriodFor(entry))
+	entry.Tainted = true
+	entry.MountState = mountStatePendingDeletion
+	entry.PurgeTime = purgeTime.Unix()
+
+	if err := c.persistMounts(ctx, c.mounts, &entry.Local); err != nil {
+		entry.Tainted = false
+		entry.MountState = ""
+		entry.PurgeTime = 0
+		c.logger.Error("failed to mark entry pending deletion in mounts table", "error", err)
+		return time.Time{}, logical.CodedError(500, "failed to mark entry pending deletion in mounts table")
+	}
+
+	// Taint the router path to prevent routing
+	if err := c.router.Taint(ctx, path); err != nil {
+		entry.Tainted = false
+		entry.MountState = ""
+		entry.PurgeTime = 0
+		if pErr := c.persistMounts(ctx, c.mounts, &entry.Local); pErr != nil {
+			c.logger.Error("failed to restore entry after tainting its path failed", "path", path, "error", pErr)
+		}
+		return time.Time{}, err
+	}
+
+	if c.logger.IsInfo() {
+		c.logger.Info("mount pending deletion", "path", path, "namespace", ns.Path, "purge_time", purgeTime)
+	}
+	return purgeTime, nil
+}
+
+// recoverMount restores a mount that is pending deletion, so that it serves
+// requests again.
+func (c *Core) recoverMount(ctx context.Context, path string) error {
+	ns, err := namespace.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Ensure we end the path in a slash
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	c.mountsLock.Lock()
+	defer c.mountsLock.Unlock()
+
+	entry, err := c.mounts.find(ctx, path)
+	if err != nil {
+		return err
+	}
+	if entry == nil || entry.MountState != mountStatePendingDeletion {
+		return fmt.Errorf("no mount pending deletion at %q", path)
+	}
+
+	// Untaint the router path first, so the stored table never says the
+	// mount is active while it still isn't routed
+	if err := c.router.Untaint(ctx, path); err != nil {
+		return err
+	}
+
+	purgeTime := entry.PurgeTime
+	entry.Tainted = false
+	entry.MountState = ""
+	entry.PurgeTime = 0
+
+	if err := c.persistMounts(ctx, c.mounts, &entry.Local); err != nil {
+		entry.Tainted = true
+		entry.MountState = mountStatePendingDeletion
+		entry.PurgeTime = purgeTime
+		if tErr := c.router.Taint(ctx, path); tErr != nil {
+			c.logger.Error("failed to taint path again after recovering its entry failed", "path", path, "error", tErr)
+		}
+		c.logger.Error("failed to recover entry in mounts table", "error", err)
+		return logical.CodedError(500, "failed to recover entry in mounts table")
+	}
+
+	if c.logger.IsInfo() {
+		c.logger.Info("recovered mount pending deletion", "path", path, "namespace", ns.Path)
+	}
+	return nil
+}
+
+// purgeExpiredMounts unmounts the mounts whose deletion grace period has
+// passed. Failures are logged and retried on the next check.
+func (c *Core) purgeExpiredMounts(ctx context.Context) {
+	now := time.Now().Unix()
+
+	var expired []*MountEntry
+	c.mountsLock.RLock()
+	for _, entry := range c.mounts.Entries {
+		if entry.MountState == mountStatePendingDeletion && entry.PurgeTime <= now {
+			expired = append(expired, entry)
+		}
+	}
+	c.mountsLock.RUnlock()
+
+	for _, entry := range expired {
+		nsCtx := namespace.ContextWithNamespace(ctx, entry.Namespace())
+		if err := c.unmount(nsCtx, entry.Path); err != nil {
+			c.logger.Error("failed to remove mount pending deletion", "path", entry.Path, "namespace", entry.Namespace().Path, "error", err)
+			continue
+		}
+		if err := c.removePathFromFilteredPaths(nsCtx, entry.Namespace().Path+entry.Path, entry.ViewPath()); err != nil {
+			c.logger.Error("filtered path removal failed", "path", entry.Path, "namespace", entry.Namespace().Path, "error", err)
+		}
+	}
+}
+
+// mountDeletionLoop periodically removes mounts whose deletion grace period
+// has passed, until stopCh is closed. It only runs on the active node.
+func (c *Core) mountDeletionLoop(stopCh chan struct{}) {
+	ticker := time.NewTicker(mountDeletionCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			l := newLockGrabber(c.stateLock.RLock, c.stateLock.RUnlock, stopCh)
+			go l.grab()
+			if stopped := l.lockOrStop(); stopped {
+				return
+			}
+			c.purgeExpiredMounts(c.activeContext)
+			c.stateLock.RUnlock()
+		}
+	}
+}
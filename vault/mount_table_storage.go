@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/sdk/physical"
+)
+
+// mountTableShardsMagic prefixes the entry stored in place of a mount table
+// that has been split into shards. It makes the entry undecodable as a mount
+// table, so that versions of Vault that don't know about shards fail to
+// unseal rather than silently load an empty table.
+//
+// Tables are only sharded once they no longer fit in a single storage entry,
+// which is when older versions would have failed to write them at all. Once a
+// table has been sharded, downgrading, or running a standby of an older
+// version during a rolling upgrade, is no longer possible until the table
+// shrinks back below the limit and is written again.
+var mountTableShardsMagic = []byte("vault-mount-table-shards:")
+
+// mountTableShardSize is the size of each shard when the storage backend
+// rejects a table as too large without reporting its limit. It leaves headroom
+// below Consul's 512KiB limit for the barrier's encryption overhead. It's a var
+// to allow for testing.
+var mountTableShardSize = 500 * 1024
+
+// mountTableEntryOverhead is subtracted from the limits reported by storage
+// backends to leave room for the barrier's encryption overhead.
+const mountTableEntryOverhead = 1024
+
+// mountTableEntrySizeLimiter is implemented by storage backends that apply
+// their own, possibly configured, size limits to mount table entries.
+type mountTableEntrySizeLimiter interface {
+	physical.MountTableLimitingBackend
+
+	// EntrySizeLimitForPath returns the largest value that can be stored at
+	// the given path.
+	EntrySizeLimitForPath(path string) uint64
+}
+
+func init() {
+	// Shards are part of the mount tables, so backends must apply the same
+	// limits to them as to the tables themselves
+	registerMountOrNamespaceTablePaths(
+		mountTableShardsPrefix(coreMountConfigPath),
+		mountTableShardsPrefix(coreLocalMountConfigPath),
+		mountTableShardsPrefix(coreAuthConfigPath),
+		mountTableShardsPrefix(coreLocalAuthConfigPath),
+	)
+}
+
+// mountTableShards describes where the shards of a mount table are stored.
+// Every write of a sharded table uses a new ID, so a table is never assembled
+// from shards belonging to different writes.
+type mountTableShards struct {
+	ID    string `json:"id"`
+	Count int    `json:"count"`
+}
+
+// mountTableShardsPrefix returns the storage prefix under which the shards of
+// the mount table stored at path are kept.
+func mountTableShardsPrefix(path string) string {
+	return path + "-shards/"
+}
+
+// getMountTableEntry reads the encoded mount table stored at path, assembling
+// it from its shards if needed.
+func (c *Core) getMountTableEntry(ctx context.Context, path string) (*logical.StorageEntry, error) {
+	entry, err := c.barrier.Get(ctx, path)
+	if err != nil || entry == nil {
+		return entry, err
+	}
+
+	if !bytes.HasPrefix(entry.Value, mountTableShardsMagic) {
+		return entry, nil
+	}
+
+	var shards mountTableShards
+	if err := json.Unmarshal(entry.Value[len(mountTableShardsMagic):], &shards); err != nil {
+		return nil, fmt.Errorf("failed to decode mount table shards for %q: %w", path, err)
+	}
+
+	var value []byte
+	prefix := mountTableShardsPrefix(path) + shards.ID + "/"
+	for i := 0; i < shards.Count; i++ {
+		shard, err := c.barrier.Get(ctx, prefix+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		if shard == nil {
+			return nil, fmt.Errorf("missing shard %d of mount table %q", i, path)
+		}
+		value = append(value, shard.Value...)
+	}
+
+	return &logical.StorageEntry{
+		Key:   path,
+		Value: value,
+	}, nil
+}
+
+// mountTableEntrySizeLimit returns the largest encoded mount table that can
+// be stored at path in a single entry, and whether the storage backend
+// reported that limit.
+func (c *Core) mountTableEntrySizeLimit(path string) (int, bool) {
+	b, ok := c.underlyingPhysical.(mountTableEntrySizeLimiter)
+	if !ok {
+		return mountTableShardSize, false
+	}
+	limit := int(b.EntrySizeLimitForPath(path)) - mountTableEntryOverhead
+	if limit <= 0 {
+		return mountTableShardSize, false
+	}
+	return limit, true
+}
+
+// putMountTableEntry writes an encoded mount table, splitting it into shards
+// only if the storage backend can't store it in a single entry. Shards are
+// written before the entry referencing them, and shards from previous writes
+// are only removed once the new table is in place, so a failed write leaves
+// the previous table intact.
+func (c *Core) putMountTableEntry(ctx context.Context, entry *logical.StorageEntry) error {
+	limit, known := c.mountTableEntrySizeLimit(entry.Key)
+	if !known || len(entry.Value) <= limit {
+		err := c.barrier.Put(ctx, entry)
+		switch {
+		case err == nil:
+			if _, ok := c.mountTablesUnsharded.Load(entry.Key); !ok {
+				c.deleteMountTableShards(ctx, entry.Key, "")
+			}
+			return nil
+		case known || !strings.Contains(err.Error(), physical.ErrValueTooLarge):
+			return err
+		}
+	}
+
+	c.mountTablesUnsharded.Delete(entry.Key)
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return err
+	}
+
+	shards := mountTableShards{ID: id}
+	prefix := mountTableShardsPrefix(entry.Key) + id + "/"
+	shardSize, _ := c.mountTableEntrySizeLimit(prefix + "0")
+	for value := entry.Value; len(value) > 0; shards.Count++ {
+		size := min(len(value), shardSize)
+		shard := &logical.StorageEntry{
+			Key:   prefix + strconv.Itoa(shards.Count),
+			Value: value[:size],
+		}
+		if err := c.barrier.Put(ctx, shard); err != nil {
+			return err
+		}
+		value = value[size:]
+	}
+
+	encoded, err := json.Marshal(shards)
+	if err != nil {
+		return err
+	}
+	if err := c.barrier.Put(ctx, &logical.StorageEntry{
+		Key:   entry.Key,
+		Value: append(bytes.Clone(mountTableShardsMagic), encoded...),
+	}); err != nil {
+		return err
+	}
+
+	c.deleteMountTableShards(ctx, entry.Key, id)
+	return nil
+}
+
+// deleteMountTableShards removes the shards of the mount table stored at path,
+// except for those belonging to the write with the given ID. Failures are only
+// logged, since stale shards are never read and will be retried on the next
+// write. Once all shards of an unsharded table have been removed, the path is
+// recorded in mountTablesUnsharded so later writes skip the listing.
+func (c *Core) deleteMountTableShards(ctx context.Context, path, keepID string) {
+	prefix := mountTableShardsPrefix(path)
+	ids, err := c.barrier.List(ctx, prefix)
+	if err != nil {
+		c.logger.Warn("failed to list stale mount table shards", "path", path, "error", err)
+		return
+	}
+
+	clean := true
+	for _, id := range ids {
+		if strings.TrimSuffix(id, "/") == keepID {
+			continue
+		}
+		keys, err := c.barrier.List(ctx, prefix+id)
+		if err != nil {
+			c.logger.Warn("failed to list stale mount table shards", "path", path, "error", err)
+			clean = false
+			continue
+		}
+		for _, key := range keys {
+			if err := c.barrier.Delete(ctx, prefix+id+key); err != nil {
+				c.logger.Warn("failed to delete stale mount table shard", "path", path, "error", err)
+				clean = false
+			}
+		}
+	}
+
+	if clean && keepID == "" {
+		c.mountTablesUnsharded.Store(path, struct{}{})
+	}
+}
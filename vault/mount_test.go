@@ -8,11 +8,13 @@ import (
 	"encoding/json"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/armon/go-metrics"
 	"github.com/go-test/deep"
+	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/vault/audit"
 	"github.com/hashicorp/vault/helper/metricsutil"
 	"github.com/hashicorp/vault/helper/namespace"
@@ -20,7 +22,10 @@ import (
 	"github.com/hashicorp/vault/helper/versions"
 	"github.com/hashicorp/vault/sdk/helper/compressutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	"github.com/hashicorp/vault/sdk/helper/logging"
 	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/sdk/physical"
+	"github.com/hashicorp/vault/sdk/physical/inmem"
 )
 
 func TestMount_ReadOnlyViewDuringMount(t *testing.T) {
@@ -192,6 +197,152 @@ func TestCore_Mount(t *testing.T) {
 	}
 }
 
+// entrySizeLimitedBackend is an inmem backend that reports a configurable
+// entry size limit for mount table paths.
+type entrySizeLimitedBackend struct {
+	physical.Backend
+	limit atomic.Uint64
+}
+
+func (b *entrySizeLimitedBackend) RegisterMountTablePath(string) {}
+
+func (b *entrySizeLimitedBackend) EntrySizeLimitForPath(string) uint64 {
+	return b.limit.Load()
+}
+
+// TestCore_Mount_Shards verifies that mount and auth tables larger than the
+// storage backend's entry size limit are split into shards that are
+// transparently reassembled, and that stale shards are removed once a table
+// fits in a single entry again.
+func TestCore_Mount_Shards(t *testing.T) {
+	inm, err := inmem.NewInmem(nil, logging.NewVaultLogger(log.Trace))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	backend := &entrySizeLimitedBackend{Backend: inm}
+	backend.limit.Store(mountTableEntryOverhead + 128)
+
+	conf := &CoreConfig{
+		Physical:        backend,
+		DisableMlock:    true,
+		BuiltinRegistry: corehelpers.NewMockBuiltinRegistry(),
+	}
+	c, err := NewCore(conf)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer c.Shutdown()
+	root, keys := TestInitUnsealCore(t, c)
+
+	ctx := namespace.RootContext(nil)
+	for _, path := range []string{"foo", "bar", "baz"} {
+		me := &MountEntry{
+			Table: mountTableType,
+			Path:  path,
+			Type:  "kv",
+		}
+		if err := c.mount(ctx, me); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	shardIDs, err := c.barrier.List(ctx, mountTableShardsPrefix(coreMountConfigPath))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(shardIDs) != 1 {
+		t.Fatalf("expected shards from a single write, got: %v", shardIDs)
+	}
+
+	c2, err := NewCore(conf)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer c2.Shutdown()
+	for _, key := range keys {
+		if _, err := TestCoreUnseal(c2, key); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+
+	if diff := deep.Equal(c.mounts.sortEntriesByPath(), c2.mounts.sortEntriesByPath()); len(diff) > 0 {
+		t.Fatalf("mismatch: %v", diff)
+	}
+	if diff := deep.Equal(c.auth.sortEntriesByPath(), c2.auth.sortEntriesByPath()); len(diff) > 0 {
+		t.Fatalf("mismatch: %v", diff)
+	}
+
+	backend.limit.Store(1024 * 1024)
+	c.mountsLock.Lock()
+	err = c.persistMounts(ctx, c.mounts, nil)
+	c.mountsLock.Unlock()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for _, prefix := range []string{mountTableShardsPrefix(coreMountConfigPath), mountTableShardsPrefix(coreLocalMountConfigPath)} {
+		keys, err := logical.CollectKeys(ctx, logical.NewStorageView(c.barrier, prefix))
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if len(keys) != 0 {
+			t.Fatalf("expected stale shards under %q to be removed, got: %v", prefix, keys)
+		}
+	}
+	raw, err := c.barrier.Get(ctx, coreMountConfigPath)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := c.decodeMountTable(ctx, raw.Value); err != nil {
+		t.Fatalf("expected an unsharded mount table: %v", err)
+	}
+	if _, ok := c.mountTablesUnsharded.Load(coreMountConfigPath); !ok {
+		t.Fatalf("expected %q to be recorded as unsharded", coreMountConfigPath)
+	}
+
+	if err := c.Seal(root); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, ok := c.mountTablesUnsharded.Load(coreMountConfigPath); ok {
+		t.Fatalf("expected %q to be forgotten on seal", coreMountConfigPath)
+	}
+}
+
+// TestCore_Mount_ShardsOnlyWhenTooLarge verifies that a mount table is
+// stored in a single entry whenever the storage backend accepts it, so that
+// older versions can still read it.
+func TestCore_Mount_ShardsOnlyWhenTooLarge(t *testing.T) {
+	oldShardSize := mountTableShardSize
+	mountTableShardSize = 128
+	t.Cleanup(func() { mountTableShardSize = oldShardSize })
+
+	c, _, _ := TestCoreUnsealed(t)
+	ctx := namespace.RootContext(nil)
+	me := &MountEntry{
+		Table: mountTableType,
+		Path:  "foo",
+		Type:  "kv",
+	}
+	if err := c.mount(ctx, me); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	raw, err := c.barrier.Get(ctx, coreMountConfigPath)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := c.decodeMountTable(ctx, raw.Value); err != nil {
+		t.Fatalf("expected an unsharded mount table: %v", err)
+	}
+	shardIDs, err := c.barrier.List(ctx, mountTableShardsPrefix(coreMountConfigPath))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(shardIDs) != 0 {
+		t.Fatalf("expected no shards, got: %v", shardIDs)
+	}
+}
+
 func TestCore_Mount_secrets_builtin_RunningVersion(t *testing.T) {
 	c, _, _ := TestCoreUnsealed(t)
 	me := &MountEntry{
@@ -20,7 +20,8 @@ var registeredMountOrNamespaceTableKeys []string
 // natural place alongside the rest of the code that matters for that subsystem.
 // We need to know them centrally within NewCore so that we can register them
 // with Backends that want to apply different limits to mount table entries and
-// namespace config.
+// namespace config. Paths ending in a slash are prefixes, covering every key
+// stored under them.
 func registerMountOrNamespaceTablePaths(paths ...string) {
 	registeredMountOrNamespaceTableKeys = append(registeredMountOrNamespaceTableKeys, paths...)
 }
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -17,11 +18,14 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/go-cleanhttp"
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/go-secure-stdlib/base62"
 	semver "github.com/hashicorp/go-version"
+	"github.com/hashicorp/vault/helper/pgpkeys"
 	"github.com/hashicorp/vault/helper/versions"
 	v4 "github.com/hashicorp/vault/sdk/database/dbplugin"
 	v5 "github.com/hashicorp/vault/sdk/database/dbplugin/v5"
@@ -69,6 +73,11 @@ type PluginCatalog struct {
 	wrapper pluginutil.RunnerUtil
 
 	runtimeCatalog *PluginRuntimeCatalog
+
+	// downloadKeyring holds the PGP keys trusted to sign downloaded plugin
+	// binaries, and httpClient is used to fetch them.
+	downloadKeyring openpgp.EntityList
+	httpClient      *http.Client
 }
 
 // Only plugins running with identical PluginRunner config can be multiplexed,
@@ -151,10 +160,18 @@ type PluginCatalogInput struct {
 	Tmpdir               string
 	EnableMlock          bool
 	PluginRuntimeCatalog *PluginRuntimeCatalog
+	// DownloadPGPKeys are the base64-encoded PGP public keys trusted to sign
+	// plugins registered by download URL.
+	DownloadPGPKeys []string
 }
 
 func SetupPluginCatalog(ctx context.Context, in *PluginCatalogInput) (*PluginCatalog, error) {
 	logger := in.Logger
+	downloadKeyring, err := pgpkeys.GetEntities(in.DownloadPGPKeys)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing plugin download PGP keys: %w", err)
+	}
+
 	catalog := &PluginCatalog{
 		builtinRegistry: in.BuiltinRegistry,
 		catalogView:     in.CatalogView,
@@ -164,15 +181,23 @@ func SetupPluginCatalog(ctx context.Context, in *PluginCatalogInput) (*PluginCat
 		mlockPlugins:    in.EnableMlock,
 		wrapper:         logical.StaticSystemView{VersionString: version.GetVersion().Version},
 		runtimeCatalog:  in.PluginRuntimeCatalog,
+		downloadKeyring: downloadKeyring,
+		httpClient:      cleanhttp.DefaultPooledClient(),
 	}
+	catalog.httpClient.Timeout = pluginDownloadTimeout
 
 	// Run upgrade if untyped plugins exist
-	err := catalog.upgradePlugins(ctx, logger)
+	err = catalog.upgradePlugins(ctx, logger)
 	if err != nil {
 		logger.Error("error while upgrading plugin storage", "error", err)
 		return nil, err
 	}
 
+	// Downloads need a plugin directory and trusted keys to verify them with
+	if catalog.directory != "" && len(catalog.downloadKeyring) > 0 {
+		go catalog.downloadMissingPlugins(ctx)
+	}
+
 	if legacy, _ := strconv.ParseBool(os.Getenv(pluginutil.PluginUseLegacyEnvLayering)); legacy {
 		conflicts := false
 		osKeys := envKeys(os.Environ())
@@ -907,6 +932,13 @@ func (c *PluginCatalog) get(ctx context.Context, name string, pluginType consts.
 			return entry, nil
 		case c.directory != "":
 			// Only allow returning non-container external plugins if we have a plugin directory.
+			// Downloaded binaries are only fetched at registration and in
+			// the background after setup, never on lookup.
+			if entry.DownloadURL != "" {
+				if _, err := os.Lstat(filepath.Join(c.directory, entry.Command)); err != nil {
+					return nil, fmt.Errorf("binary of downloaded plugin %q is not available, it may still be downloading: %w", name, err)
+				}
+			}
 			// Make the command path fully rooted.
 			entry.Command = filepath.Join(c.directory, entry.Command)
 			return entry, nil
@@ -952,6 +984,10 @@ func (c *PluginCatalog) Set(ctx context.Context, plugin pluginutil.SetPluginInpu
 		return consts.ErrPathContainsParentReferences
 	}
 
+	if plugin.DownloadURL != "" {
+		return c.setDownloaded(ctx, plugin)
+	}
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -1051,6 +1087,9 @@ func (c *PluginCatalog) setInternal(ctx context.Context, plugin pluginutil.SetPl
 		Env:      plugin.Env,
 		Sha256:   plugin.Sha256,
 		Builtin:  false,
+
+		DownloadURL:       plugin.DownloadURL,
+		DownloadSignature: plugin.DownloadSignature,
 	}
 
 	buf, err := json.Marshal(entry)
@@ -0,0 +1,333 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plugincatalog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/vault/sdk/helper/consts"
+	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	"github.com/hashicorp/vault/sdk/helper/pluginutil"
+)
+
+const (
+	// maxPluginDownloadSize bounds the size of a single downloaded plugin
+	// binary.
+	maxPluginDownloadSize = 1 << 30
+
+	// pluginDownloadTimeout bounds how long a single plugin download may take.
+	pluginDownloadTimeout = 10 * time.Minute
+)
+
+var (
+	ErrPluginDownloadNotConfigured = errors.New("no plugin download PGP keys are configured")
+	ErrPluginDownloadInvalid       = errors.New("invalid plugin download")
+	ErrPluginDownloadFailed        = errors.New("error downloading plugin")
+	ErrPluginDownloadVerification  = errors.New("downloaded plugin failed verification")
+)
+
+// DownloadPluginInput describes a plugin binary to fetch into the plugin
+// directory.
+type DownloadPluginInput struct {
+	// Command is the file name the binary is stored under in the plugin
+	// directory.
+	Command string
+	// URL is the HTTPS location of the plugin binary.
+	URL string
+	// Sha256 is the expected SHA256 sum of the binary.
+	Sha256 []byte
+	// Signature is a detached PGP signature of the binary made by one of the
+	// configured plugin download keys.
+	Signature []byte
+}
+
+// downloadPlugin fetches a plugin binary into a temporary file in the plugin
+// directory and returns its path once both its SHA256 sum and its detached
+// signature have been verified. The caller is responsible for moving the file
+// into place or removing it.
+func (c *PluginCatalog) downloadPlugin(ctx context.Context, in DownloadPluginInput) (string, error) {
+	if c.directory == "" {
+		return "", ErrDirectoryNotConfigured
+	}
+	if len(c.downloadKeyring) == 0 {
+		return "", ErrPluginDownloadNotConfigured
+	}
+
+	switch {
+	case strings.Contains(in.Command, ".."):
+		return "", consts.ErrPathContainsParentReferences
+	case in.Command == "" || filepath.Base(in.Command) != in.Command:
+		return "", fmt.Errorf("%w: command %q must be a file name within the plugin directory", ErrPluginDownloadInvalid, in.Command)
+	}
+
+	u, err := url.Parse(in.URL)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid URL: %s", ErrPluginDownloadInvalid, err)
+	}
+	if u.Scheme != "https" {
+		return "", fmt.Errorf("%w: URL must use https, got %q", ErrPluginDownloadInvalid, u.Scheme)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pluginDownloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrPluginDownloadInvalid, err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrPluginDownloadFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: unexpected status %q", ErrPluginDownloadFailed, resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(c.directory, "."+in.Command+".download-*")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary plugin file: %w", err)
+	}
+	success := false
+	defer func() {
+		tmp.Close()
+		if !success {
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(resp.Body, maxPluginDownloadSize+1))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrPluginDownloadFailed, err)
+	}
+	if n > maxPluginDownloadSize {
+		return "", fmt.Errorf("%w: plugin binary exceeds the maximum download size of %d bytes", ErrPluginDownloadFailed, maxPluginDownloadSize)
+	}
+
+	if subtle.ConstantTimeCompare(hash.Sum(nil), in.Sha256) != 1 {
+		return "", fmt.Errorf("%w: SHA256 sum does not match", ErrPluginDownloadVerification)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	signer, err := openpgp.CheckDetachedSignature(c.downloadKeyring, tmp, bytes.NewReader(in.Signature), nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrPluginDownloadVerification, err)
+	}
+
+	if err := tmp.Chmod(0o755); err != nil {
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	success = true
+	c.logger.Info("downloaded plugin", "command", in.Command, "url", u.Redacted(), "signer", fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint))
+	return tmp.Name(), nil
+}
+
+// setDownloaded downloads the plugin's binary and registers it. The download
+// happens before the catalog's write lock is taken, so a slow download server
+// doesn't block plugin lookups. A file already in the plugin directory is only
+// replaced if it was downloaded for the same catalog entry. If the
+// registration fails, the binary that was previously in place, if any, is
+// restored.
+func (c *PluginCatalog) setDownloaded(ctx context.Context, plugin pluginutil.SetPluginInput) error {
+	tmp, err := c.downloadPlugin(ctx, DownloadPluginInput{
+		Command:   plugin.Command,
+		URL:       plugin.DownloadURL,
+		Sha256:    plugin.Sha256,
+		Signature: plugin.DownloadSignature,
+	})
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Keep a link to the existing binary so it can be restored if the new one
+	// can't be registered.
+	dst := filepath.Join(c.directory, plugin.Command)
+	backup := ""
+	if _, err := os.Lstat(dst); err == nil {
+		owned, err := c.ownsDownloadedBinary(ctx, plugin)
+		if err != nil {
+			return err
+		}
+		if !owned {
+			return fmt.Errorf("%w: %q already exists in the plugin directory and was not downloaded for this plugin", ErrPluginDownloadInvalid, plugin.Command)
+		}
+
+		backup = tmp + ".previous"
+		if err := os.Link(dst, backup); err != nil {
+			return fmt.Errorf("error backing up existing plugin binary: %w", err)
+		}
+		defer os.Remove(backup)
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		return fmt.Errorf("error moving downloaded plugin into place: %w", err)
+	}
+
+	if _, err := c.setInternal(ctx, plugin); err != nil {
+		if backup != "" {
+			if rErr := os.Rename(backup, dst); rErr != nil {
+				c.logger.Error("failed to restore previous plugin binary", "command", plugin.Command, "error", rErr)
+			}
+		} else {
+			os.Remove(dst)
+		}
+		return err
+	}
+	return nil
+}
+
+// ownsDownloadedBinary reports whether the plugin's command in the plugin
+// directory belongs to the catalog entry being registered, i.e. it was
+// downloaded for the same name and version, and no other entry uses it. This
+// should be called with the write lock held.
+func (c *PluginCatalog) ownsDownloadedBinary(ctx context.Context, plugin pluginutil.SetPluginInput) (bool, error) {
+	plugins, err := c.collectAllPlugins(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	owned := false
+	for _, p := range plugins {
+		if p.Command != plugin.Command {
+			continue
+		}
+		if p.DownloadURL == "" || p.Name != plugin.Name || p.Version != plugin.Version {
+			return false, nil
+		}
+		if plugin.Type != consts.PluginTypeUnknown && p.Type != plugin.Type {
+			return false, nil
+		}
+		owned = true
+	}
+	return owned, nil
+}
+
+// downloadMissingPlugins fetches the binaries of plugins registered with a
+// download URL that are missing from this node's plugin directory, e.g. after
+// another node handled the registration, or that no longer match their SHA256
+// sum. It runs in the background once the catalog is set up, so unseal doesn't
+// wait on download servers; until a binary is in place, looking up its plugin
+// fails. Failures are only logged: the affected plugins are fetched again on
+// the next unseal, or when they are registered again.
+func (c *PluginCatalog) downloadMissingPlugins(ctx context.Context) {
+	if c.directory == "" {
+		return
+	}
+
+	c.lock.RLock()
+	plugins, err := c.collectAllPlugins(ctx)
+	c.lock.RUnlock()
+	if err != nil {
+		if ctx.Err() == nil {
+			c.logger.Error("error collecting plugins to download", "error", err)
+		}
+		return
+	}
+
+	for _, plugin := range plugins {
+		if ctx.Err() != nil {
+			return
+		}
+		if plugin.DownloadURL == "" {
+			continue
+		}
+
+		dst := filepath.Join(c.directory, plugin.Command)
+		sum, err := fileSha256(dst)
+		switch {
+		case err == nil && subtle.ConstantTimeCompare(sum, plugin.Sha256) == 1:
+			continue
+		case err == nil:
+			c.logger.Warn("binary of downloaded plugin does not match its SHA256 sum, downloading it again", "name", plugin.Name, "type", plugin.Type, "version", plugin.Version)
+		case !errors.Is(err, fs.ErrNotExist):
+			c.logger.Error("error verifying binary of downloaded plugin", "name", plugin.Name, "type", plugin.Type, "version", plugin.Version, "error", err)
+			continue
+		}
+
+		tmp, err := c.downloadPlugin(ctx, DownloadPluginInput{
+			Command:   plugin.Command,
+			URL:       plugin.DownloadURL,
+			Sha256:    plugin.Sha256,
+			Signature: plugin.DownloadSignature,
+		})
+		if err != nil {
+			c.logger.Error("failed to download missing plugin", "name", plugin.Name, "type", plugin.Type, "version", plugin.Version, "error", err)
+			continue
+		}
+		if err := c.installDownloadedPlugin(ctx, plugin, tmp); err != nil {
+			os.Remove(tmp)
+			c.logger.Error("error moving downloaded plugin into place", "name", plugin.Name, "type", plugin.Type, "version", plugin.Version, "error", err)
+		}
+	}
+}
+
+// installDownloadedPlugin moves a binary downloaded for the given catalog
+// entry into place, as long as the entry hasn't changed since the download
+// started.
+func (c *PluginCatalog) installDownloadedPlugin(ctx context.Context, plugin *pluginutil.PluginRunner, tmp string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	storageKey := path.Join(plugin.Type.String(), plugin.Name)
+	if plugin.Version != "" {
+		storageKey = path.Join(storageKey, plugin.Version)
+	}
+	out, err := c.catalogView.Get(ctx, storageKey)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return errors.New("plugin was removed from the catalog")
+	}
+	current := new(pluginutil.PluginRunner)
+	if err := jsonutil.DecodeJSON(out.Value, current); err != nil {
+		return fmt.Errorf("failed to decode plugin entry: %w", err)
+	}
+	if current.Command != plugin.Command || !bytes.Equal(current.Sha256, plugin.Sha256) {
+		return errors.New("plugin was registered again while it was being downloaded")
+	}
+
+	return os.Rename(tmp, filepath.Join(c.directory, plugin.Command))
+}
+
+// fileSha256 returns the SHA256 sum of the file at the given path.
+func fileSha256(name string) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,302 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plugincatalog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/hashicorp/vault/helper/pgpkeys"
+	"github.com/hashicorp/vault/sdk/helper/consts"
+	"github.com/hashicorp/vault/sdk/helper/pluginutil"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/require"
+)
+
+// testDownloadCatalog returns a plugin catalog that trusts pgpkeys.TestPubKey1
+// for plugin downloads, along with a TLS server that serves body.
+func testDownloadCatalog(t *testing.T, body []byte) (*PluginCatalog, *httptest.Server) {
+	t.Helper()
+
+	catalog := testPluginCatalog(t)
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	catalog.directory = dir
+
+	keyring, err := pgpkeys.GetEntities([]string{pgpkeys.TestPubKey1})
+	require.NoError(t, err)
+	catalog.downloadKeyring = keyring
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	catalog.httpClient = srv.Client()
+
+	return catalog, srv
+}
+
+// testDetachSign signs data with the given base64-encoded PGP private key.
+func testDetachSign(t *testing.T, privKey string, data []byte) []byte {
+	t.Helper()
+
+	keyBytes, err := base64.StdEncoding.DecodeString(privKey)
+	require.NoError(t, err)
+	entity, err := openpgp.ReadEntity(packet.NewReader(bytes.NewReader(keyBytes)))
+	require.NoError(t, err)
+
+	var sig bytes.Buffer
+	require.NoError(t, openpgp.DetachSign(&sig, entity, bytes.NewReader(data), nil))
+	return sig.Bytes()
+}
+
+// TestPluginCatalog_DownloadPlugin tests that a downloaded plugin is only moved
+// into the plugin directory when both its checksum and signature verify.
+func TestPluginCatalog_DownloadPlugin(t *testing.T) {
+	body := []byte("#!/bin/sh\necho plugin\n")
+	sum := sha256.Sum256(body)
+	goodSig := testDetachSign(t, pgpkeys.TestPrivKey1, body)
+	untrustedSig := testDetachSign(t, pgpkeys.TestPrivKey2, body)
+
+	tests := map[string]struct {
+		sha256    []byte
+		signature []byte
+		wantErr   error
+	}{
+		"valid": {
+			sha256:    sum[:],
+			signature: goodSig,
+		},
+		"checksum mismatch": {
+			sha256:    make([]byte, sha256.Size),
+			signature: goodSig,
+			wantErr:   ErrPluginDownloadVerification,
+		},
+		"untrusted signer": {
+			sha256:    sum[:],
+			signature: untrustedSig,
+			wantErr:   ErrPluginDownloadVerification,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			catalog, srv := testDownloadCatalog(t, body)
+
+			tmp, err := catalog.downloadPlugin(context.Background(), DownloadPluginInput{
+				Command:   "my-plugin",
+				URL:       srv.URL + "/my-plugin",
+				Sha256:    tc.sha256,
+				Signature: tc.signature,
+			})
+
+			entries, readErr := os.ReadDir(catalog.directory)
+			require.NoError(t, readErr)
+
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.Empty(t, entries, "failed downloads must not leave files behind")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			require.Equal(t, catalog.directory, filepath.Dir(tmp))
+			got, err := os.ReadFile(tmp)
+			require.NoError(t, err)
+			require.Equal(t, body, got)
+		})
+	}
+}
+
+// TestPluginCatalog_DownloadPlugin_Validation tests the checks performed before
+// any download is attempted.
+func TestPluginCatalog_DownloadPlugin_Validation(t *testing.T) {
+	catalog, srv := testDownloadCatalog(t, nil)
+
+	tests := map[string]DownloadPluginInput{
+		"parent reference": {Command: "../my-plugin", URL: srv.URL},
+		"nested command":   {Command: "dir/my-plugin", URL: srv.URL},
+		"plain http":       {Command: "my-plugin", URL: "http://example.com/my-plugin"},
+	}
+	for name, in := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := catalog.downloadPlugin(context.Background(), in)
+			require.Error(t, err)
+		})
+	}
+
+	catalog.downloadKeyring = nil
+	_, err := catalog.downloadPlugin(context.Background(), DownloadPluginInput{Command: "my-plugin", URL: srv.URL})
+	require.ErrorIs(t, err, ErrPluginDownloadNotConfigured)
+}
+
+// TestPluginCatalog_Set_DownloadFailure tests that a downloaded binary that
+// can't be registered doesn't replace the binary that was there before.
+func TestPluginCatalog_Set_DownloadFailure(t *testing.T) {
+	body := []byte("#!/bin/sh\necho plugin\n")
+	sum := sha256.Sum256(body)
+	sig := testDetachSign(t, pgpkeys.TestPrivKey1, body)
+
+	for name, previous := range map[string][]byte{
+		"no previous binary": nil,
+		"previous binary":    []byte("previous"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			catalog, srv := testDownloadCatalog(t, body)
+			if previous != nil {
+				require.NoError(t, os.WriteFile(filepath.Join(catalog.directory, "my-plugin"), previous, 0o755))
+				testPutDownloadedPlugin(t, catalog, &pluginutil.PluginRunner{
+					Name:        "my-plugin",
+					Type:        consts.PluginTypeSecrets,
+					Command:     "my-plugin",
+					DownloadURL: srv.URL + "/my-plugin",
+				})
+			}
+
+			// The type of a shell script can't be determined, so it can't be
+			// registered.
+			err := catalog.Set(context.Background(), pluginutil.SetPluginInput{
+				Name:              "my-plugin",
+				Type:              consts.PluginTypeUnknown,
+				Command:           "my-plugin",
+				Sha256:            sum[:],
+				DownloadURL:       srv.URL + "/my-plugin",
+				DownloadSignature: sig,
+			})
+			require.Error(t, err)
+
+			entries, err := os.ReadDir(catalog.directory)
+			require.NoError(t, err)
+			if previous == nil {
+				require.Empty(t, entries)
+				return
+			}
+			require.Len(t, entries, 1)
+			got, err := os.ReadFile(filepath.Join(catalog.directory, "my-plugin"))
+			require.NoError(t, err)
+			require.Equal(t, previous, got)
+		})
+	}
+}
+
+// TestPluginCatalog_Set_DownloadNotOwned tests that a download doesn't replace
+// a file in the plugin directory that wasn't downloaded for the same plugin.
+func TestPluginCatalog_Set_DownloadNotOwned(t *testing.T) {
+	body := []byte("#!/bin/sh\necho plugin\n")
+	sum := sha256.Sum256(body)
+	sig := testDetachSign(t, pgpkeys.TestPrivKey1, body)
+	previous := []byte("previous")
+
+	tests := map[string]*pluginutil.PluginRunner{
+		"not in the catalog": nil,
+		"not downloaded": {
+			Name:    "my-plugin",
+			Type:    consts.PluginTypeSecrets,
+			Command: "my-plugin",
+		},
+		"downloaded for another plugin": {
+			Name:        "other-plugin",
+			Type:        consts.PluginTypeSecrets,
+			Command:     "my-plugin",
+			DownloadURL: "https://example.com/other-plugin",
+		},
+	}
+	for name, existing := range tests {
+		t.Run(name, func(t *testing.T) {
+			catalog, srv := testDownloadCatalog(t, body)
+			require.NoError(t, os.WriteFile(filepath.Join(catalog.directory, "my-plugin"), previous, 0o755))
+			if existing != nil {
+				testPutDownloadedPlugin(t, catalog, existing)
+			}
+
+			err := catalog.Set(context.Background(), pluginutil.SetPluginInput{
+				Name:              "my-plugin",
+				Type:              consts.PluginTypeSecrets,
+				Command:           "my-plugin",
+				Sha256:            sum[:],
+				DownloadURL:       srv.URL + "/my-plugin",
+				DownloadSignature: sig,
+			})
+			require.ErrorIs(t, err, ErrPluginDownloadInvalid)
+
+			entries, err := os.ReadDir(catalog.directory)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			got, err := os.ReadFile(filepath.Join(catalog.directory, "my-plugin"))
+			require.NoError(t, err)
+			require.Equal(t, previous, got)
+		})
+	}
+}
+
+// testPutDownloadedPlugin stores a catalog entry directly, without running or
+// downloading its plugin.
+func testPutDownloadedPlugin(t *testing.T, catalog *PluginCatalog, entry *pluginutil.PluginRunner) {
+	t.Helper()
+
+	buf, err := json.Marshal(entry)
+	require.NoError(t, err)
+	key := path.Join(entry.Type.String(), entry.Name)
+	if entry.Version != "" {
+		key = path.Join(key, entry.Version)
+	}
+	require.NoError(t, catalog.catalogView.Put(context.Background(), &logical.StorageEntry{
+		Key:   key,
+		Value: buf,
+	}))
+}
+
+// TestPluginCatalog_DownloadMissingPlugins tests that a plugin registered with
+// a download URL is fetched when the catalog is set up if its binary is
+// missing, as on a node that didn't handle the registration, or doesn't match
+// its SHA256 sum, and that lookups fail rather than download it.
+func TestPluginCatalog_DownloadMissingPlugins(t *testing.T) {
+	body := []byte("#!/bin/sh\necho plugin\n")
+	sum := sha256.Sum256(body)
+	catalog, srv := testDownloadCatalog(t, body)
+
+	testPutDownloadedPlugin(t, catalog, &pluginutil.PluginRunner{
+		Name:              "my-plugin",
+		Type:              consts.PluginTypeSecrets,
+		Version:           "v1.0.0",
+		Command:           "my-plugin-v1.0.0",
+		Sha256:            sum[:],
+		DownloadURL:       srv.URL + "/my-plugin",
+		DownloadSignature: testDetachSign(t, pgpkeys.TestPrivKey1, body),
+	})
+
+	_, err := catalog.Get(context.Background(), "my-plugin", consts.PluginTypeSecrets, "v1.0.0")
+	require.Error(t, err)
+	entries, err := os.ReadDir(catalog.directory)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	catalog.downloadMissingPlugins(context.Background())
+
+	runner, err := catalog.Get(context.Background(), "my-plugin", consts.PluginTypeSecrets, "v1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(catalog.directory, "my-plugin-v1.0.0"), runner.Command)
+	got, err := os.ReadFile(runner.Command)
+	require.NoError(t, err)
+	require.Equal(t, body, got)
+
+	// A binary that doesn't match its SHA256 sum is replaced
+	require.NoError(t, os.WriteFile(runner.Command, []byte("tampered"), 0o755))
+	catalog.downloadMissingPlugins(context.Background())
+	got, err = os.ReadFile(runner.Command)
+	require.NoError(t, err)
+	require.Equal(t, body, got)
+}
@@ -7,8 +7,10 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/armon/go-metrics"
@@ -32,6 +34,10 @@ const (
 	// policyCacheSize is the number of policies that are kept cached
 	policyCacheSize = 1024
 
+	// aclCacheSize is the number of distinct policy sets whose constructed
+	// ACLs are kept cached
+	aclCacheSize = 1024
+
 	// defaultPolicyName is the name of the default policy
 	defaultPolicyName = "default"
 
@@ -186,6 +192,15 @@ type PolicyStore struct {
 	tokenPoliciesLRU *lru.TwoQueueCache
 	egpLRU           *lru.TwoQueueCache
 
+	// aclLRU caches ACLs constructed from sets of named policies, so that
+	// tokens sharing the same policies don't rebuild the same ACL on every
+	// request. It is purged whenever any policy changes, and aclGeneration is
+	// bumped so ACLs built from stale policies are not added back. The
+	// generation is bumped even if the cache is disabled, since it's also
+	// used to invalidate other cached permission decisions.
+	aclLRU        *lru.TwoQueueCache
+	aclGeneration atomic.Uint64
+
 	// This is used to ensure that writes to the store (acl/rgp) or to the egp
 	// path tree don't happen concurrently. We are okay reading stale data so
 	// long as there aren't concurrent writes.
@@ -227,6 +242,8 @@ func NewPolicyStore(ctx context.Context, core *Core, baseView *BarrierView, syst
 		ps.tokenPoliciesLRU = cache
 		cache, _ = lru.New2Q(policyCacheSize)
 		ps.egpLRU = cache
+		cache, _ = lru.New2Q(aclCacheSize)
+		ps.aclLRU = cache
 	}
 
 	aclView := ps.getACLView(namespace.RootNamespace)
@@ -309,6 +326,9 @@ func (ps *PolicyStore) invalidate(ctx context.Context, name string, policyType P
 	ps.modifyLock.Lock()
 	defer ps.modifyLock.Unlock()
 
+	ps.purgeACLCache()
+	defer ps.purgeACLCache()
+
 	// We don't lock before removing from the LRU here because the worst that
 	// can happen is we load again if something since added it
 	switch policyType {
@@ -366,6 +386,11 @@ func (ps *PolicyStore) setPolicyInternal(ctx context.Context, p *Policy) error {
 	ps.modifyLock.Lock()
 	defer ps.modifyLock.Unlock()
 
+	// Purge again once the policy LRU and storage have been updated, since an
+	// ACL built in the meantime may still have used the previous policy
+	ps.purgeACLCache()
+	defer ps.purgeACLCache()
+
 	// Get the appropriate view based on policy type and namespace
 	view := ps.getBarrierView(p.namespace, p.Type)
 	if view == nil {
@@ -756,6 +781,9 @@ func (ps *PolicyStore) switchedDeletePolicy(ctx context.Context, name string, po
 		defer ps.modifyLock.Unlock()
 	}
 
+	ps.purgeACLCache()
+	defer ps.purgeACLCache()
+
 	// Policies are normalized to lower-case
 	name = ps.sanitizeName(name)
 	index := ps.cacheKey(ns, name)
@@ -831,6 +859,106 @@ func (ps *PolicyStore) switchedDeletePolicy(ctx context.Context, name string, po
 // ACL is used to return an ACL which is built using the
 // named policies and pre-fetched policies if given.
 func (ps *PolicyStore) ACL(ctx context.Context, entity *identity.Entity, policyNames map[string][]string, additionalPolicies ...*Policy) (*ACL, error) {
+	// Only ACLs built purely from named policies can be shared between
+	// tokens; inline policies are specific to a single token
+	var cacheKey string
+	var generation uint64
+	if ps.aclLRU != nil && len(additionalPolicies) == 0 {
+		ns, err := namespace.FromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cacheKey = aclCacheKey(ns, policyNames)
+		generation = ps.aclGeneration.Load()
+		if raw, ok := ps.aclLRU.Get(cacheKey); ok {
+			return raw.(*ACL), nil
+		}
+	}
+
+	allPolicies, err := ps.resolvePolicies(ctx, entity, policyNames, additionalPolicies...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Construct the ACL
+	acl, err := NewACL(ctx, allPolicies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct ACL: %w", err)
+	}
+
+	if cacheKey != "" && aclCacheable(allPolicies) {
+		ps.aclLRU.Add(cacheKey, acl)
+		// If a policy changed while we were building the ACL, it may have
+		// been built from stale policies
+		if ps.aclGeneration.Load() != generation {
+			ps.aclLRU.Remove(cacheKey)
+		}
+	}
+
+	return acl, nil
+}
+
+// purgeACLCache drops all cached ACLs. It must be called both before and
+// after a policy changes: ACLs built while the change is in progress may use
+// either version of the policy, and the second call makes sure they're not
+// kept.
+func (ps *PolicyStore) purgeACLCache() {
+	ps.aclGeneration.Add(1)
+	if ps.aclLRU == nil {
+		return
+	}
+	ps.aclLRU.Purge()
+}
+
+// ACLGeneration returns a counter that changes whenever any policy changes, so
+// that callers caching permission decisions can tell when they may be stale.
+func (c *Core) ACLGeneration() uint64 {
+	if c.policyStore == nil {
+		return 0
+	}
+	return c.policyStore.aclGeneration.Load()
+}
+
+// aclCacheKey returns the key under which the ACL built in ns from
+// policyNames is cached. It does not depend on the order of policyNames.
+func aclCacheKey(ns *namespace.Namespace, policyNames map[string][]string) string {
+	nsIDs := make([]string, 0, len(policyNames))
+	for nsID := range policyNames {
+		nsIDs = append(nsIDs, nsID)
+	}
+	sort.Strings(nsIDs)
+
+	var b strings.Builder
+	b.WriteString(ns.ID)
+	for _, nsID := range nsIDs {
+		names := make([]string, len(policyNames[nsID]))
+		for i, name := range policyNames[nsID] {
+			names[i] = strings.ToLower(name)
+		}
+		sort.Strings(names)
+		b.WriteString("\x00")
+		b.WriteString(nsID)
+		b.WriteString("\x00")
+		b.WriteString(strings.Join(strutil.RemoveDuplicates(names, false), ","))
+	}
+	return b.String()
+}
+
+// aclCacheable reports whether an ACL built from policies may be reused for
+// other tokens with the same policy names. Templated policies depend on the
+// token's entity, and RGPs are evaluated per request.
+func aclCacheable(policies []*Policy) bool {
+	for _, p := range policies {
+		if p.Type != PolicyTypeACL || p.Templated {
+			return false
+		}
+	}
+	return true
+}
+
+// resolvePolicies fetches the named policies and renders any templated ones
+// for the given entity, returning them along with additionalPolicies.
+func (ps *PolicyStore) resolvePolicies(ctx context.Context, entity *identity.Entity, policyNames map[string][]string, additionalPolicies ...*Policy) ([]*Policy, error) {
 	var allPolicies []*Policy
 
 	// Fetch the named policies
@@ -876,17 +1004,12 @@ func (ps *PolicyStore) ACL(ctx context.Context, entity *identity.Entity, policyN
 				return nil, fmt.Errorf("error parsing templated policy %q: %w", policy.Name, err)
 			}
 			p.Name = policy.Name
+			p.Templated = true
 			allPolicies[i] = p
 		}
 	}
 
-	// Construct the ACL
-	acl, err := NewACL(ctx, allPolicies)
-	if err != nil {
-		return nil, fmt.Errorf("failed to construct ACL: %w", err)
-	}
-
-	return acl, nil
+	return allPolicies, nil
 }
 
 // loadACLPolicy is used to load default ACL policies. The default policies will
@@ -5,11 +5,16 @@ package vault
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/helper/testhelpers/corehelpers"
 	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/hashicorp/vault/sdk/physical"
+	physInmem "github.com/hashicorp/vault/sdk/physical/inmem"
 	"github.com/stretchr/testify/require"
 )
 
@@ -280,6 +285,126 @@ func testPolicyStoreACL(t *testing.T, ps *PolicyStore, ns *namespace.Namespace)
 	testLayeredACL(t, acl, ns)
 }
 
+// TestPolicyStore_ACLCache verifies that ACLs built from named policies are
+// reused until any policy changes, and that ACLs depending on the token are
+// never shared.
+func TestPolicyStore_ACLCache(t *testing.T) {
+	_, ps := mockPolicyWithCore(t, false)
+	ctx := namespace.RootContext(context.Background())
+	ns := namespace.RootNamespace
+
+	setPolicy := func(raw string) {
+		t.Helper()
+		policy, err := ParseACLPolicy(ns, raw)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if err := ps.SetPolicy(ctx, policy); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	}
+	getACL := func(names map[string][]string, additional ...*Policy) *ACL {
+		t.Helper()
+		acl, err := ps.ACL(ctx, nil, names, additional...)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		return acl
+	}
+
+	setPolicy(`name = "app"
+path "secret/app" { capabilities = ["read"] }`)
+	setPolicy(`name = "ops"
+path "secret/ops" { capabilities = ["read"] }`)
+
+	acl := getACL(map[string][]string{ns.ID: {"app", "ops"}})
+	if again := getACL(map[string][]string{ns.ID: {"ops", "app"}}); again != acl {
+		t.Fatal("expected the ACL for the same policy set to be reused")
+	}
+	if other := getACL(map[string][]string{ns.ID: {"app"}}); other == acl {
+		t.Fatal("expected a different policy set to get its own ACL")
+	}
+
+	// Changing any policy must invalidate cached ACLs
+	setPolicy(`name = "app"
+path "secret/app" { capabilities = ["update"] }`)
+	acl = getACL(map[string][]string{ns.ID: {"app", "ops"}})
+	if caps := acl.Capabilities(ctx, "secret/app"); !reflect.DeepEqual(caps, []string{"update"}) {
+		t.Fatalf("expected updated capabilities, got %v", caps)
+	}
+
+	if err := ps.DeletePolicy(ctx, "ops", PolicyTypeACL); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	acl = getACL(map[string][]string{ns.ID: {"app", "ops"}})
+	if caps := acl.Capabilities(ctx, "secret/ops"); !reflect.DeepEqual(caps, []string{"deny"}) {
+		t.Fatalf("expected deleted policy to no longer apply, got %v", caps)
+	}
+
+	// Templated policies depend on the entity, and inline policies on the
+	// token, so neither may be shared
+	setPolicy(`name = "templated"
+path "secret/{{identity.entity.id}}" { capabilities = ["read"] }`)
+	templated := map[string][]string{ns.ID: {"templated"}}
+	if getACL(templated) == getACL(templated) {
+		t.Fatal("expected templated policies not to be cached")
+	}
+	inline, err := ParseACLPolicy(ns, `path "secret/inline" { capabilities = ["read"] }`)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	names := map[string][]string{ns.ID: {"app"}}
+	if getACL(names, inline) == getACL(names, inline) {
+		t.Fatal("expected ACLs with inline policies not to be cached")
+	}
+}
+
+// TestPolicyStore_ACLCache_ConcurrentSet verifies that an ACL built while a
+// policy is being updated isn't kept in the cache once the update completes.
+func TestPolicyStore_ACLCache_ConcurrentSet(t *testing.T) {
+	logger := corehelpers.NewTestLogger(t)
+	inm, err := physInmem.NewInmem(nil, logger)
+	require.NoError(t, err)
+	latency := physical.NewLatencyInjector(inm, 0, 0, logger)
+	core, err := NewCore(testCoreConfig(t, latency, logger))
+	require.NoError(t, err)
+	t.Cleanup(func() { core.Shutdown() })
+	testCoreUnsealed(t, core)
+
+	ps := core.policyStore
+	ctx := namespace.RootContext(context.Background())
+	ns := namespace.RootNamespace
+	names := map[string][]string{ns.ID: {"app"}}
+
+	setPolicy := func(capability string) error {
+		policy, err := ParseACLPolicy(ns, fmt.Sprintf(`name = "app"
+path "secret/app" { capabilities = [%q] }`, capability))
+		if err != nil {
+			return err
+		}
+		return ps.SetPolicy(ctx, policy)
+	}
+
+	require.NoError(t, setPolicy("read"))
+	_, err = ps.ACL(ctx, nil, names)
+	require.NoError(t, err)
+
+	// Slow down storage so that the ACL below is built, from the previous
+	// policy, while the update is still in progress
+	latency.SetLatency(200 * time.Millisecond)
+	errCh := make(chan error, 1)
+	go func() { errCh <- setPolicy("update") }()
+	time.Sleep(100 * time.Millisecond)
+	_, err = ps.ACL(ctx, nil, names)
+	require.NoError(t, err)
+	require.NoError(t, <-errCh)
+	latency.SetLatency(0)
+
+	acl, err := ps.ACL(ctx, nil, names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"update"}, acl.Capabilities(ctx, "secret/app"))
+}
+
 func TestDefaultPolicy(t *testing.T) {
 	ctx := namespace.ContextWithNamespace(context.Background(), namespace.RootNamespace)
 
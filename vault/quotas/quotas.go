@@ -293,9 +293,9 @@ func NewManager(logger log.Logger, walkFunc leaseWalkFunc, ms *metricsutil.Clust
 
 	if detectDeadlocks {
 		logger.Debug("enabling deadlock detection")
-		manager.quotaLock = &locking.DeadlockRWMutex{}
-		manager.quotaConfigLock = &locking.DeadlockRWMutex{}
-		manager.dbAndCacheLock = &locking.DeadlockRWMutex{}
+		manager.quotaLock = &locking.DeadlockRWMutex{Name: "quotas"}
+		manager.quotaConfigLock = &locking.DeadlockRWMutex{Name: "quotas.config"}
+		manager.dbAndCacheLock = &locking.DeadlockRWMutex{Name: "quotas.db_and_cache"}
 	}
 
 	manager.init(walkFunc)
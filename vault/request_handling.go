@@ -30,6 +30,7 @@ import (
 	"github.com/hashicorp/vault/helper/identity/mfa"
 	"github.com/hashicorp/vault/helper/metricsutil"
 	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/helper/tracing"
 	"github.com/hashicorp/vault/http/priority"
 	"github.com/hashicorp/vault/internalshared/configutil"
 	"github.com/hashicorp/vault/sdk/framework"
@@ -41,6 +42,7 @@ import (
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/hashicorp/vault/vault/quotas"
 	"github.com/hashicorp/vault/vault/tokens"
+	"go.opentelemetry.io/otel/attribute"
 	uberAtomic "go.uber.org/atomic"
 )
 
@@ -633,6 +635,15 @@ func (c *Core) handleCancelableRequest(ctx context.Context, req *logical.Request
 	// as it is depended on by some functionality (e.g. quotas)
 	req.MountPoint = c.router.MatchingMount(ctx, req.Path)
 
+	ctx, span := tracing.StartSpan(ctx, "core.handle_request",
+		attribute.String("vault.path", req.Path),
+		attribute.String("vault.operation", string(req.Operation)),
+		attribute.String("vault.mount_point", req.MountPoint),
+	)
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	// Decrement the wait group when our request is done
 	if waitGroup != nil {
 		defer waitGroup.Done()
@@ -1055,6 +1066,7 @@ func (c *Core) handleRequest(ctx context.Context, req *logical.Request) (retResp
 			retErr = multierror.Append(retErr, logical.ErrPermissionDenied, logical.ErrInvalidToken)
 			return nil, nil, retErr
 		}
+		c.tokenUsage.record(ns, te, time.Now())
 		if te.NumUses == tokenRevocationPending {
 			// We defer a revocation until after logic has run, since this is a
 			// valid request (this is the token's final use). We pass the ID in
@@ -1774,7 +1786,7 @@ func (c *Core) handleLoginRequest(ctx context.Context, req *logical.Request) (re
 				for _, eConfig := range matchedMfaEnforcementList {
 					err = c.validateLoginMFA(ctx, eConfig, entity, req.Connection.RemoteAddr, req.MFACreds)
 					if err != nil {
-						return nil, nil, logical.ErrPermissionDenied
+						return nil, nil, logical.WithErrorCode(logical.ErrPermissionDenied, logical.ErrorCodeMFAFailed)
 					}
 				}
 			} else if len(matchedMfaEnforcementList) > 0 && len(req.MFACreds) == 0 {
@@ -50,6 +50,11 @@ type RollbackManager struct {
 	router *Router
 	period time.Duration
 
+	// lastTriggered records when the periodic rollback was last scheduled
+	// for each mount, to honor per mount rollback intervals. It is only
+	// accessed from run.
+	lastTriggered map[string]time.Time
+
 	rollbackMetricsMountName bool
 	inflightAll              sync.WaitGroup
 	inflight                 map[string]*rollbackState
@@ -88,6 +93,7 @@ func NewRollbackManager(ctx context.Context, logger log.Logger, backendsFunc fun
 		backends:                 backendsFunc,
 		router:                   router,
 		period:                   core.rollbackPeriod,
+		lastTriggered:            make(map[string]time.Time),
 		inflight:                 make(map[string]*rollbackState),
 		doneCh:                   make(chan struct{}),
 		shutdownCh:               make(chan struct{}),
@@ -173,9 +179,13 @@ func (m *RollbackManager) run() {
 	}
 }
 
-// triggerRollbacks is used to trigger the rollbacks across all the backends
+// triggerRollbacks is used to trigger the rollbacks across all the backends.
+// Mounts tuned with a rollback interval longer than the manager's period are
+// skipped until that interval has passed since their last scheduled rollback.
 func (m *RollbackManager) triggerRollbacks() {
 	backends := m.backends()
+	now := time.Now()
+	seen := make(map[string]struct{}, len(backends))
 
 	for _, e := range backends {
 		path := e.Path
@@ -190,10 +200,25 @@ func (m *RollbackManager) triggerRollbacks() {
 			continue
 		}
 		fullPath := e.namespace.Path + path
+		seen[fullPath] = struct{}{}
+
+		if interval := e.Config.RollbackInterval; interval > m.period {
+			if last, ok := m.lastTriggered[fullPath]; ok && now.Sub(last) < interval {
+				continue
+			}
+		}
+		m.lastTriggered[fullPath] = now
 
 		// Start a rollback if necessary
 		m.startOrLookupRollback(ctx, fullPath, true)
 	}
+
+	// Forget mounts that no longer exist
+	for fullPath := range m.lastTriggered {
+		if _, ok := seen[fullPath]; !ok {
+			delete(m.lastTriggered, fullPath)
+		}
+	}
 }
 
 // lookupRollbackLocked checks if there's an inflight rollback with the given
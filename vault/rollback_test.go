@@ -83,6 +83,21 @@ func TestRollbackManager(t *testing.T) {
 	}
 }
 
+// TestRollbackManager_RollbackInterval verifies that a mount with a rollback
+// interval longer than the manager's period is rolled back less often.
+func TestRollbackManager_RollbackInterval(t *testing.T) {
+	m, backend := mockRollback(t)
+	m.backends()[0].Config.RollbackInterval = time.Hour
+
+	m.Start()
+	time.Sleep(50 * time.Millisecond)
+	m.Stop()
+
+	backend.Lock()
+	defer backend.Unlock()
+	require.Len(t, backend.Paths, 1)
+}
+
 // TestRollbackManager_ManyWorkers adds 10 backends that require a rollback
 // operation, with 20 workers. The test verifies that the 10
 // work items will run in parallel
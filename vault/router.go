@@ -16,10 +16,13 @@ import (
 	"github.com/armon/go-radix"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"github.com/hashicorp/vault/helper/metricsutil"
 	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/helper/tracing"
 	"github.com/hashicorp/vault/sdk/helper/consts"
 	"github.com/hashicorp/vault/sdk/helper/salt"
 	"github.com/hashicorp/vault/sdk/logical"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var deniedPassthroughRequestHeaders = []string{
@@ -787,7 +790,16 @@ func (r *Router) routeCommon(ctx context.Context, req *logical.Request, existenc
 		ok, exists, err := re.backend.HandleExistenceCheck(ctx, req)
 		return nil, ok, exists, err
 	} else {
-		resp, err := re.backend.HandleRequest(ctx, req)
+		start := time.Now()
+		backendCtx, span := tracing.StartSpan(ctx, "backend.handle_request",
+			attribute.String("vault.mount_point", strings.TrimPrefix(mount, ns.Path)),
+			attribute.String("vault.mount_type", re.mountEntry.Type),
+		)
+		resp, err := re.backend.HandleRequest(backendCtx, req)
+		tracing.EndSpan(span, err)
+		if req.Operation != logical.RollbackOperation {
+			emitRouteRequestMetrics(ns, strings.TrimPrefix(mount, ns.Path), req.Operation, start, resp, err)
+		}
 		if resp != nil {
 			if len(allowedResponseHeaders) > 0 {
 				resp.Headers = filteredHeaders(resp.Headers, allowedResponseHeaders, nil)
@@ -847,6 +859,22 @@ func (r *Router) routeCommon(ctx context.Context, req *logical.Request, existenc
 	}
 }
 
+// emitRouteRequestMetrics records the latency and, on failure, an error count
+// for a request handled by the backend mounted at mountPoint. Unlike the
+// per-mount route metrics, the mount point, operation, and namespace are
+// labels so they can be aggregated.
+func emitRouteRequestMetrics(ns *namespace.Namespace, mountPoint string, op logical.Operation, start time.Time, resp *logical.Response, err error) {
+	labels := []metrics.Label{
+		metricsutil.NamespaceLabel(ns),
+		{Name: "mount_point", Value: mountPoint},
+		{Name: "operation", Value: string(op)},
+	}
+	metrics.MeasureSinceWithLabels([]string{"route", "request"}, start, labels)
+	if err != nil || resp.IsError() {
+		metrics.IncrCounterWithLabels([]string{"route", "error"}, 1, labels)
+	}
+}
+
 // RootPath checks if the given path requires root privileges
 func (r *Router) RootPath(ctx context.Context, path string) bool {
 	ns, err := namespace.FromContext(ctx)
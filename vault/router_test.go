@@ -8,11 +8,14 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/vault/helper/namespace"
 	"github.com/hashicorp/vault/sdk/logical"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRouter_Mount(t *testing.T) {
@@ -127,6 +130,69 @@ func TestRouter_Mount(t *testing.T) {
 	}
 }
 
+// TestRouter_RequestMetrics verifies that routed requests emit the labeled
+// request latency metric, and that failed requests are also counted as errors.
+// This test cannot be run in parallel, because we are using the global metrics
+// instance
+func TestRouter_RequestMetrics(t *testing.T) {
+	inMemSink := metrics.NewInmemSink(10000*time.Hour, 10000*time.Hour)
+	_, err := metrics.NewGlobal(metrics.DefaultConfig("vault"), inMemSink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRouter()
+	_, barrier, _ := mockBarrier(t)
+	view := NewBarrierView(barrier, "logical/")
+
+	meUUID, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := &NoopBackend{}
+	err = r.Mount(n, "prod/aws/", &MountEntry{Path: "prod/aws/", UUID: meUUID, Accessor: "awsaccessor", NamespaceID: namespace.RootNamespaceID, namespace: namespace.RootNamespace}, view)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if _, err := r.Route(namespace.RootContext(nil), &logical.Request{Path: "prod/aws/foo", Operation: logical.ReadOperation}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	n.Response = logical.ErrorResponse("boom")
+	if _, err := r.Route(namespace.RootContext(nil), &logical.Request{Path: "prod/aws/foo", Operation: logical.UpdateOperation}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	expectedLabels := func(op string) []metrics.Label {
+		return []metrics.Label{
+			{Name: "namespace", Value: "root"},
+			{Name: "mount_point", Value: "prod/aws/"},
+			{Name: "operation", Value: op},
+		}
+	}
+
+	intervals := inMemSink.Data()
+	var requests, errorCounters []metrics.SampledValue
+	for _, interval := range intervals {
+		for _, sample := range interval.Samples {
+			if sample.Name == "vault.route.request" {
+				requests = append(requests, sample)
+			}
+		}
+		for _, counter := range interval.Counters {
+			if counter.Name == "vault.route.error" {
+				errorCounters = append(errorCounters, counter)
+			}
+		}
+	}
+
+	require.Len(t, requests, 2)
+	assert.ElementsMatch(t, [][]metrics.Label{expectedLabels("read"), expectedLabels("update")}, [][]metrics.Label{requests[0].Labels, requests[1].Labels})
+	require.Len(t, errorCounters, 1)
+	assert.Equal(t, expectedLabels("update"), errorCounters[0].Labels)
+	assert.Equal(t, 1, errorCounters[0].Count)
+}
+
 func TestRouter_MountCredential(t *testing.T) {
 	r := NewRouter()
 	_, barrier, _ := mockBarrier(t)
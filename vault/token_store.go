@@ -413,6 +413,30 @@ func (ts *TokenStore) paths() []*framework.Path {
 			HelpDescription: strings.TrimSpace(tokenRevokeAccessorHelp),
 		},
 
+		{
+			Pattern: "destroy-cubbyhole-accessor$",
+
+			DisplayAttrs: &framework.DisplayAttributes{
+				OperationPrefix: operationPrefixToken,
+				OperationVerb:   "destroy-cubbyhole",
+				OperationSuffix: "accessor",
+			},
+
+			Fields: map[string]*framework.FieldSchema{
+				"accessor": {
+					Type:        framework.TypeString,
+					Description: "Accessor of the token (request body)",
+				},
+			},
+
+			Callbacks: map[logical.Operation]framework.OperationFunc{
+				logical.UpdateOperation: ts.handleUpdateDestroyCubbyholeAccessor,
+			},
+
+			HelpSynopsis:    strings.TrimSpace(tokenDestroyCubbyholeAccessorHelp),
+			HelpDescription: strings.TrimSpace(tokenDestroyCubbyholeAccessorHelp),
+		},
+
 		{
 			Pattern: "revoke-self$",
 
@@ -991,22 +1015,22 @@ func (ts *TokenStore) tokenStoreAccessorList(ctx context.Context, req *logical.R
 
 // createAccessor is used to create an identifier for the token ID.
 // A storage index, mapping the accessor to the token ID is also created.
-func (ts *TokenStore) createAccessor(ctx context.Context, entry *logical.TokenEntry) error {
+func (ts *TokenStore) createAccessor(ctx context.Context, entry *logical.TokenEntry) (barrierViewEntry, error) {
 	defer metrics.MeasureSince([]string{"token", "createAccessor"}, time.Now())
 
 	var err error
 	// Create a random accessor
 	entry.Accessor, err = base62.Random(TokenLength)
 	if err != nil {
-		return err
+		return barrierViewEntry{}, err
 	}
 
 	tokenNS, err := NamespaceByID(ctx, entry.NamespaceID, ts.core)
 	if err != nil {
-		return err
+		return barrierViewEntry{}, err
 	}
 	if tokenNS == nil {
-		return namespace.ErrNoNamespace
+		return barrierViewEntry{}, namespace.ErrNoNamespace
 	}
 
 	if tokenNS.ID != namespace.RootNamespaceID {
@@ -1017,7 +1041,7 @@ func (ts *TokenStore) createAccessor(ctx context.Context, entry *logical.TokenEn
 	saltCtx := namespace.ContextWithNamespace(ctx, tokenNS)
 	saltID, err := ts.SaltID(saltCtx, entry.Accessor)
 	if err != nil {
-		return err
+		return barrierViewEntry{}, err
 	}
 
 	aEntry := &accessorEntry{
@@ -1028,14 +1052,13 @@ func (ts *TokenStore) createAccessor(ctx context.Context, entry *logical.TokenEn
 
 	aEntryBytes, err := jsonutil.EncodeJSON(aEntry)
 	if err != nil {
-		return fmt.Errorf("failed to marshal accessor index entry: %w", err)
+		return barrierViewEntry{}, fmt.Errorf("failed to marshal accessor index entry: %w", err)
 	}
 
-	le := &logical.StorageEntry{Key: saltID, Value: aEntryBytes}
-	if err := ts.accessorView(tokenNS).Put(ctx, le); err != nil {
-		return fmt.Errorf("failed to persist accessor index entry: %w", err)
-	}
-	return nil
+	return barrierViewEntry{
+		view:  ts.accessorView(tokenNS),
+		entry: &logical.StorageEntry{Key: saltID, Value: aEntryBytes},
+	}, nil
 }
 
 // Create is used to create a new token entry. The entry is assigned
@@ -1129,15 +1152,22 @@ func (ts *TokenStore) create(ctx context.Context, entry *logical.TokenEntry) err
 			}
 		}
 
-		err = ts.createAccessor(ctx, entry)
+		accessorEntry, err := ts.createAccessor(ctx, entry)
 		if err != nil {
 			return err
 		}
 
-		err = ts.storeCommon(ctx, entry, true)
+		entries, err := ts.storageEntries(ctx, entry, true)
 		if err != nil {
 			return err
 		}
+
+		// Write the accessor, parent index and token entries together, so
+		// they cost a single storage round trip on transactional backends
+		entries = append([]barrierViewEntry{accessorEntry}, entries...)
+		if err := putBarrierViewEntries(ctx, entries); err != nil {
+			return fmt.Errorf("failed to persist entry: %w", err)
+		}
 		entry.ExternalID = entry.ID
 		if !userSelectedID && !ts.core.DisableSSCTokens() {
 			entry.ExternalID = ts.GenerateSSCTokenID(entry.ID, logical.IndexStateFromContext(ctx), entry)
@@ -1306,26 +1336,41 @@ func (ts *TokenStore) store(ctx context.Context, entry *logical.TokenEntry) erro
 // storeCommon handles the actual storage of an entry, possibly generating
 // secondary indexes
 func (ts *TokenStore) storeCommon(ctx context.Context, entry *logical.TokenEntry, writeSecondary bool) error {
-	tokenNS, err := NamespaceByID(ctx, entry.NamespaceID, ts.core)
+	entries, err := ts.storageEntries(ctx, entry, writeSecondary)
 	if err != nil {
 		return err
 	}
+	if err := putBarrierViewEntries(ctx, entries); err != nil {
+		return fmt.Errorf("failed to persist entry: %w", err)
+	}
+	return nil
+}
+
+// storageEntries returns the storage entries needed to persist a token entry,
+// in the order they should be written if they can't be written atomically.
+func (ts *TokenStore) storageEntries(ctx context.Context, entry *logical.TokenEntry, writeSecondary bool) ([]barrierViewEntry, error) {
+	tokenNS, err := NamespaceByID(ctx, entry.NamespaceID, ts.core)
+	if err != nil {
+		return nil, err
+	}
 	if tokenNS == nil {
-		return namespace.ErrNoNamespace
+		return nil, namespace.ErrNoNamespace
 	}
 
 	saltCtx := namespace.ContextWithNamespace(ctx, tokenNS)
 	saltedID, err := ts.SaltID(saltCtx, entry.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Marshal the entry
 	enc, err := json.Marshal(entry)
 	if err != nil {
-		return fmt.Errorf("failed to encode entry: %w", err)
+		return nil, fmt.Errorf("failed to encode entry: %w", err)
 	}
 
+	var entries []barrierViewEntry
+
 	if writeSecondary {
 		// Write the secondary index if necessary. This is done before the
 		// primary index because we'd rather have a dangling pointer with
@@ -1335,18 +1380,18 @@ func (ts *TokenStore) storeCommon(ctx context.Context, entry *logical.TokenEntry
 			// Ensure the parent exists
 			parent, err := ts.Lookup(ctx, entry.Parent)
 			if err != nil {
-				return fmt.Errorf("failed to lookup parent: %w", err)
+				return nil, fmt.Errorf("failed to lookup parent: %w", err)
 			}
 			if parent == nil {
-				return fmt.Errorf("parent token not found")
+				return nil, fmt.Errorf("parent token not found")
 			}
 
 			parentNS, err := NamespaceByID(ctx, parent.NamespaceID, ts.core)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if parentNS == nil {
-				return namespace.ErrNoNamespace
+				return nil, namespace.ErrNoNamespace
 			}
 
 			parentCtx := namespace.ContextWithNamespace(ctx, parentNS)
@@ -1354,7 +1399,7 @@ func (ts *TokenStore) storeCommon(ctx context.Context, entry *logical.TokenEntry
 			// Create the index entry
 			parentSaltedID, err := ts.SaltID(parentCtx, entry.Parent)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			path := parentSaltedID + "/" + saltedID
@@ -1362,10 +1407,10 @@ func (ts *TokenStore) storeCommon(ctx context.Context, entry *logical.TokenEntry
 				path = fmt.Sprintf("%s.%s", path, tokenNS.ID)
 			}
 
-			le := &logical.StorageEntry{Key: path}
-			if err := ts.parentView(parentNS).Put(ctx, le); err != nil {
-				return fmt.Errorf("failed to persist entry: %w", err)
-			}
+			entries = append(entries, barrierViewEntry{
+				view:  ts.parentView(parentNS),
+				entry: &logical.StorageEntry{Key: path},
+			})
 		}
 	}
 
@@ -1374,10 +1419,7 @@ func (ts *TokenStore) storeCommon(ctx context.Context, entry *logical.TokenEntry
 	if len(entry.Policies) == 1 && entry.Policies[0] == "root" {
 		le.SealWrap = true
 	}
-	if err := ts.idView(tokenNS).Put(ctx, le); err != nil {
-		return fmt.Errorf("failed to persist entry: %w", err)
-	}
-	return nil
+	return append(entries, barrierViewEntry{view: ts.idView(tokenNS), entry: le}), nil
 }
 
 // UseToken is used to manage restricted use tokens and decrement their
@@ -2594,6 +2636,50 @@ func (ts *TokenStore) handleUpdateRevokeAccessor(ctx context.Context, req *logic
 	return nil, nil
 }
 
+// handleUpdateDestroyCubbyholeAccessor handles the
+// auth/token/destroy-cubbyhole-accessor path for destroying the cubbyhole of
+// the token associated with the accessor, without revoking the token itself
+func (ts *TokenStore) handleUpdateDestroyCubbyholeAccessor(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	accessor := data.Get("accessor").(string)
+	if accessor == "" {
+		return nil, &logical.StatusBadRequest{Err: "missing accessor"}
+	}
+
+	aEntry, err := ts.lookupByAccessor(ctx, accessor, false, true)
+	if err != nil {
+		return nil, err
+	}
+	if aEntry == nil {
+		resp := &logical.Response{}
+		resp.AddWarning("No token found with this accessor")
+		return resp, nil
+	}
+
+	te, err := ts.Lookup(ctx, aEntry.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	if te == nil {
+		return logical.ErrorResponse("token not found"), logical.ErrInvalidRequest
+	}
+
+	tokenNS, err := NamespaceByID(ctx, te.NamespaceID, ts.core)
+	if err != nil {
+		return nil, err
+	}
+	if tokenNS == nil {
+		return nil, namespace.ErrNoNamespace
+	}
+
+	destroyCtx := namespace.ContextWithNamespace(ctx, tokenNS)
+	if err := ts.cubbyholeDestroyer(destroyCtx, ts, te); err != nil {
+		return nil, fmt.Errorf("failed to destroy cubbyhole: %w", err)
+	}
+
+	ts.logger.Info("destroyed token cubbyhole", "accessor", accessor, "namespace", tokenNS.Path, "requested_by", req.DisplayName)
+	return nil, nil
+}
+
 // handleCreate handles the auth/token/create path for creation of new orphan
 // tokens
 func (ts *TokenStore) handleCreateOrphan(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
@@ -4195,21 +4281,22 @@ it.
 Client tokens are used to identify a client and to allow Vault to associate policies and ACLs
 which are enforced on every request. This backend also allows for generating sub-tokens as well
 as revocation of tokens. The tokens are renewable if associated with a lease.`
-	tokenCreateHelp          = `The token create path is used to create new tokens.`
-	tokenCreateOrphanHelp    = `The token create path is used to create new orphan tokens.`
-	tokenCreateRoleHelp      = `This token create path is used to create new tokens adhering to the given role.`
-	tokenListRolesHelp       = `This endpoint lists configured roles.`
-	tokenLookupAccessorHelp  = `This endpoint will lookup a token associated with the given accessor and its properties. Response will not contain the token ID.`
-	tokenRenewAccessorHelp   = `This endpoint will renew a token associated with the given accessor and its properties. Response will not contain the token ID.`
-	tokenLookupHelp          = `This endpoint will lookup a token and its properties.`
-	tokenPathRolesHelp       = `This endpoint allows creating, reading, and deleting roles.`
-	tokenRevokeAccessorHelp  = `This endpoint will delete the token associated with the accessor and all of its child tokens.`
-	tokenRevokeHelp          = `This endpoint will delete the given token and all of its child tokens.`
-	tokenRevokeSelfHelp      = `This endpoint will delete the token used to call it and all of its child tokens.`
-	tokenRevokeOrphanHelp    = `This endpoint will delete the token and orphan its child tokens.`
-	tokenRenewHelp           = `This endpoint will renew the given token and prevent expiration.`
-	tokenRenewSelfHelp       = `This endpoint will renew the token used to call it and prevent expiration.`
-	tokenAllowedPoliciesHelp = `If set, tokens can be created with any subset of the policies in this
+	tokenCreateHelp                   = `The token create path is used to create new tokens.`
+	tokenCreateOrphanHelp             = `The token create path is used to create new orphan tokens.`
+	tokenCreateRoleHelp               = `This token create path is used to create new tokens adhering to the given role.`
+	tokenListRolesHelp                = `This endpoint lists configured roles.`
+	tokenLookupAccessorHelp           = `This endpoint will lookup a token associated with the given accessor and its properties. Response will not contain the token ID.`
+	tokenRenewAccessorHelp            = `This endpoint will renew a token associated with the given accessor and its properties. Response will not contain the token ID.`
+	tokenLookupHelp                   = `This endpoint will lookup a token and its properties.`
+	tokenPathRolesHelp                = `This endpoint allows creating, reading, and deleting roles.`
+	tokenRevokeAccessorHelp           = `This endpoint will delete the token associated with the accessor and all of its child tokens.`
+	tokenDestroyCubbyholeAccessorHelp = `This endpoint will delete the cubbyhole of the token associated with the accessor. The token itself is not revoked.`
+	tokenRevokeHelp                   = `This endpoint will delete the given token and all of its child tokens.`
+	tokenRevokeSelfHelp               = `This endpoint will delete the token used to call it and all of its child tokens.`
+	tokenRevokeOrphanHelp             = `This endpoint will delete the token and orphan its child tokens.`
+	tokenRenewHelp                    = `This endpoint will renew the given token and prevent expiration.`
+	tokenRenewSelfHelp                = `This endpoint will renew the token used to call it and prevent expiration.`
+	tokenAllowedPoliciesHelp          = `If set, tokens can be created with any subset of the policies in this
 list, rather than the normal semantics of tokens being a subset of the
 calling token's policies. The parameter is a comma-delimited string of
 policy names.`
@@ -1006,6 +1006,64 @@ func TestTokenStore_HandleRequest_Renew_Revoke_Accessor(t *testing.T) {
 	}
 }
 
+func TestTokenStore_HandleRequest_DestroyCubbyholeAccessor(t *testing.T) {
+	c, _, root := TestCoreUnsealed(t)
+	ts := c.tokenStore
+	ctx := namespace.RootContext(nil)
+
+	testMakeServiceTokenViaCore(t, c, root, "tokenid", "", []string{"foo"})
+	te, err := ts.Lookup(ctx, "tokenid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if te == nil {
+		t.Fatal("token not found")
+	}
+
+	cubbyReq := &logical.Request{
+		Operation:   logical.UpdateOperation,
+		Path:        "cubbyhole/foo",
+		ClientToken: te.ID,
+		Data: map[string]interface{}{
+			"bar": "baz",
+		},
+	}
+	resp, err := c.HandleRequest(ctx, cubbyReq)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: resp: %#v\nerr: %v", resp, err)
+	}
+
+	req := logical.TestRequest(t, logical.UpdateOperation, "destroy-cubbyhole-accessor")
+	req.Data = map[string]interface{}{
+		"accessor": te.Accessor,
+	}
+	resp, err = ts.HandleRequest(ctx, req)
+	if err != nil || (resp != nil && resp.IsError()) {
+		t.Fatalf("bad: resp: %#v\nerr: %v", resp, err)
+	}
+
+	// The token must remain valid while its cubbyhole is gone
+	cubbyReq.Operation = logical.ReadOperation
+	cubbyReq.Data = nil
+	resp, err = c.HandleRequest(ctx, cubbyReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp != nil {
+		t.Fatalf("expected cubbyhole to be empty, got: %#v", resp)
+	}
+
+	// Unknown accessors only produce a warning
+	req.Data["accessor"] = "unknown"
+	resp, err = ts.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp == nil || len(resp.Warnings) != 1 {
+		t.Fatalf("expected a warning, got: %#v", resp)
+	}
+}
+
 func TestTokenStore_RootToken(t *testing.T) {
 	c, _, _ := TestCoreUnsealed(t)
 	ts := c.tokenStore
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"hash/fnv"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"github.com/hashicorp/vault/helper/metricsutil"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	// tokenUsageWindow is the length of the window over which per-token
+	// request counts are compared against the token's baseline.
+	tokenUsageWindow = time.Minute
+
+	// tokenUsageBaselineWeight is the weight a completed window carries in
+	// the token's exponentially weighted baseline.
+	tokenUsageBaselineWeight = 0.2
+
+	// tokenUsageWarmupWindows is the number of windows a token must have been
+	// seen for before its baseline is trusted.
+	tokenUsageWarmupWindows = 3
+
+	// tokenUsageSpikeFactor is how many times its baseline a token's request
+	// count must reach within a window to be considered anomalous.
+	tokenUsageSpikeFactor = 10
+
+	// tokenUsageMinRequests keeps low-volume tokens from being flagged for
+	// small absolute changes, such as going from one request to ten.
+	tokenUsageMinRequests = 100
+
+	// tokenUsageMaxTracked bounds the number of tokens tracked at once. The
+	// least recently used tokens are forgotten first.
+	tokenUsageMaxTracked = 10000
+)
+
+// tokenUsageShards is the number of independently locked shards tokens are
+// spread across by accessor, so requests made with different tokens rarely
+// contend on the same lock.
+const tokenUsageShards = 32
+
+// eventTypeTokenAnomalousUsage is sent when a token's request rate spikes
+// above its baseline.
+const eventTypeTokenAnomalousUsage logical.EventType = "token/anomalous-usage"
+
+// tokenUsage is the request history of a single token.
+type tokenUsage struct {
+	windowStart time.Time
+	count       uint64
+	baseline    float64
+	windows     int
+	flagged     bool
+}
+
+// tokenUsageShard tracks the tokens whose accessors hash to it.
+type tokenUsageShard struct {
+	l      sync.Mutex
+	tokens *simplelru.LRU[string, *tokenUsage]
+}
+
+// tokenUsageTracker counts requests per token and flags tokens whose request
+// rate suddenly exceeds their own historical baseline, which can be a sign
+// that the token has been leaked.
+type tokenUsageTracker struct {
+	shards [tokenUsageShards]*tokenUsageShard
+
+	logger     log.Logger
+	metricSink *metricsutil.ClusterMetricSink
+	sendEvent  func(ns *namespace.Namespace, eventType logical.EventType, metadataPairs ...string)
+}
+
+func newTokenUsageTracker(logger log.Logger, sink *metricsutil.ClusterMetricSink, sendEvent func(*namespace.Namespace, logical.EventType, ...string)) *tokenUsageTracker {
+	t := &tokenUsageTracker{
+		logger:     logger,
+		metricSink: sink,
+		sendEvent:  sendEvent,
+	}
+	for i := range t.shards {
+		// Only fails for a non-positive size
+		tokens, _ := simplelru.NewLRU[string, *tokenUsage](tokenUsageMaxTracked/tokenUsageShards, nil)
+		t.shards[i] = &tokenUsageShard{tokens: tokens}
+	}
+	return t
+}
+
+// shard returns the shard tracking the token with the given accessor.
+func (t *tokenUsageTracker) shard(accessor string) *tokenUsageShard {
+	h := fnv.New32a()
+	h.Write([]byte(accessor))
+	return t.shards[h.Sum32()%tokenUsageShards]
+}
+
+// get returns the tracked usage of the token with the given accessor.
+func (t *tokenUsageTracker) get(accessor string) (*tokenUsage, bool) {
+	shard := t.shard(accessor)
+	shard.l.Lock()
+	defer shard.l.Unlock()
+	return shard.tokens.Get(accessor)
+}
+
+// record counts a request made with te at now, and returns true if this
+// request pushed the token over its baseline. A token is flagged at most once
+// per window. Tokens without an accessor, such as batch tokens, are not
+// tracked.
+func (t *tokenUsageTracker) record(ns *namespace.Namespace, te *logical.TokenEntry, now time.Time) bool {
+	if t == nil || te == nil || te.Accessor == "" {
+		return false
+	}
+
+	shard := t.shard(te.Accessor)
+	shard.l.Lock()
+	usage, ok := shard.tokens.Get(te.Accessor)
+	if !ok {
+		usage = &tokenUsage{windowStart: now}
+		shard.tokens.Add(te.Accessor, usage)
+	}
+
+	if elapsed := now.Sub(usage.windowStart); elapsed >= tokenUsageWindow {
+		if usage.windows == 0 {
+			usage.baseline = float64(usage.count)
+		} else {
+			usage.baseline = tokenUsageBaselineWeight*float64(usage.count) + (1-tokenUsageBaselineWeight)*usage.baseline
+		}
+		// Windows without any requests pull the baseline towards zero
+		idle := int(elapsed/tokenUsageWindow) - 1
+		usage.baseline *= math.Pow(1-tokenUsageBaselineWeight, float64(idle))
+		usage.windows += idle + 1
+		usage.windowStart = now
+		usage.count = 0
+		usage.flagged = false
+	}
+	usage.count++
+
+	anomalous := !usage.flagged &&
+		usage.windows >= tokenUsageWarmupWindows &&
+		usage.count >= tokenUsageMinRequests &&
+		float64(usage.count) > tokenUsageSpikeFactor*usage.baseline
+	if anomalous {
+		usage.flagged = true
+	}
+	count, baseline := usage.count, usage.baseline
+	shard.l.Unlock()
+
+	if !anomalous {
+		return false
+	}
+
+	t.logger.Warn("token request rate exceeds its baseline", "accessor", te.Accessor, "namespace", ns.Path,
+		"policies", te.Policies, "requests", count, "baseline", math.Round(baseline), "window", tokenUsageWindow)
+	if t.metricSink != nil {
+		t.metricSink.IncrCounterWithLabels([]string{"token", "anomalous_usage"}, 1, []metrics.Label{
+			metricsutil.NamespaceLabel(ns),
+			{Name: "token_type", Value: te.Type.String()},
+		})
+	}
+	if t.sendEvent != nil {
+		t.sendEvent(ns, eventTypeTokenAnomalousUsage,
+			logical.EventMetadataOperation, string(eventTypeTokenAnomalousUsage),
+			"accessor", te.Accessor,
+			"token_type", te.Type.String(),
+			"requests", strconv.FormatUint(count, 10),
+			"baseline", strconv.FormatFloat(math.Round(baseline), 'f', -1, 64),
+			"window", tokenUsageWindow.String(),
+		)
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package vault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vault/helper/metricsutil"
+	"github.com/hashicorp/vault/helper/namespace"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTokenUsageTracker_FlagsSpike verifies that a token is flagged once when
+// its request count jumps well above its baseline, and that steady usage and
+// tokens without a history are left alone.
+func TestTokenUsageTracker_FlagsSpike(t *testing.T) {
+	inmemSink := metrics.NewInmemSink(time.Hour, time.Hour)
+	var events []logical.EventType
+	sendEvent := func(_ *namespace.Namespace, eventType logical.EventType, _ ...string) {
+		events = append(events, eventType)
+	}
+	tracker := newTokenUsageTracker(log.NewNullLogger(), metricsutil.NewClusterMetricSink("test", inmemSink), sendEvent)
+	te := &logical.TokenEntry{Accessor: "accessor", Type: logical.TokenTypeService}
+	ns := namespace.RootNamespace
+
+	// recordWindow makes n requests within the window starting at start and
+	// returns how many of them were flagged.
+	recordWindow := func(te *logical.TokenEntry, start time.Time, n int) int {
+		flagged := 0
+		for i := 0; i < n; i++ {
+			if tracker.record(ns, te, start.Add(time.Duration(i)*time.Millisecond)) {
+				flagged++
+			}
+		}
+		return flagged
+	}
+
+	start := time.Now()
+	for i := 0; i < tokenUsageWarmupWindows; i++ {
+		require.Zero(t, recordWindow(te, start.Add(time.Duration(i)*tokenUsageWindow), 20))
+	}
+
+	// A spike well above the baseline is flagged exactly once per window
+	require.Equal(t, 1, recordWindow(te, start.Add(tokenUsageWarmupWindows*tokenUsageWindow), 1000))
+
+	// A token without enough history is never flagged, however busy it is
+	newToken := &logical.TokenEntry{Accessor: "new-accessor", Type: logical.TokenTypeService}
+	require.Zero(t, recordWindow(newToken, start, 1000))
+
+	// Batch tokens have no accessor and are not tracked
+	require.False(t, tracker.record(ns, &logical.TokenEntry{Type: logical.TokenTypeBatch}, start))
+
+	intervals := inmemSink.Data()
+	require.Len(t, intervals, 1)
+	var found bool
+	for _, counter := range intervals[0].Counters {
+		if counter.Name == "token.anomalous_usage" {
+			found = true
+			require.Equal(t, 1, counter.Count)
+		}
+	}
+	require.True(t, found, "expected the anomalous usage counter to be emitted")
+	require.Equal(t, []logical.EventType{eventTypeTokenAnomalousUsage}, events)
+}
+
+// TestTokenUsageTracker_IdleWindowsDecayBaseline verifies that windows in
+// which a token was not used lower its baseline.
+func TestTokenUsageTracker_IdleWindowsDecayBaseline(t *testing.T) {
+	tracker := newTokenUsageTracker(log.NewNullLogger(), metricsutil.BlackholeSink(), nil)
+	te := &logical.TokenEntry{Accessor: "accessor", Type: logical.TokenTypeService}
+	ns := namespace.RootNamespace
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		tracker.record(ns, te, start)
+	}
+	tracker.record(ns, te, start.Add(tokenUsageWindow))
+	usage, ok := tracker.get(te.Accessor)
+	require.True(t, ok)
+	require.Equal(t, float64(100), usage.baseline)
+
+	tracker.record(ns, te, start.Add(5*tokenUsageWindow))
+	require.Less(t, usage.baseline, float64(50))
+	require.Equal(t, 5, usage.windows)
+}
@@ -300,11 +300,16 @@ DONELISTHANDLING:
 		"creation_ttl":  resp.WrapInfo.TTL,
 		"creation_time": creationTime,
 	}
-	// Store creation_path if not a rewrap
+	// Store creation_path and creator_accessor if not a rewrap, otherwise
+	// carry over the values of the original wrapping token
 	if req.Path != "sys/wrapping/rewrap" {
 		cubbyReq.Data["creation_path"] = req.Path
+		cubbyReq.Data["creator_accessor"] = req.ClientTokenAccessor
 	} else {
 		cubbyReq.Data["creation_path"] = resp.WrapInfo.CreationPath
+		if creatorAccessor, ok := resp.Data["creator_accessor"]; ok {
+			cubbyReq.Data["creator_accessor"] = creatorAccessor
+		}
 	}
 	cubbyResp, err = c.router.Route(ctx, cubbyReq)
 	if err != nil {